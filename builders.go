@@ -0,0 +1,217 @@
+package dgwidgets
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PageOption configures the pages generated by the page builders
+type PageOption func(*pageOptions)
+
+// pageOptions are the options of generated pages
+type pageOptions struct {
+	template *discordgo.MessageEmbed
+}
+
+// WithTemplate copies the author, thumbnail, colour and footer of
+// embed onto every generated page, so the pages look alike.
+//    embed: embed to copy the styling from
+func WithTemplate(embed *discordgo.MessageEmbed) PageOption {
+	return func(o *pageOptions) {
+		o.template = embed
+	}
+}
+
+// newPage returns an empty page styled with the options
+func newPage(opts []PageOption) *discordgo.MessageEmbed {
+	var o pageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.template == nil {
+		return &discordgo.MessageEmbed{}
+	}
+	return copyEmbed(&discordgo.MessageEmbed{
+		Author:    o.template.Author,
+		Thumbnail: o.template.Thumbnail,
+		Color:     o.template.Color,
+		Footer:    o.template.Footer,
+	})
+}
+
+// AddPaginatedText splits text into embed pages without breaking words
+// and adds them to the paginator.
+//    text           : text to split
+//    maxCharsPerPage: maximum length of each page's description
+//                     (if set to 0 or less, or above 4096, it defaults to 4096)
+//    opts           : options of the pages, e.g. WithTemplate
+func (p *Paginator) AddPaginatedText(text string, maxCharsPerPage int, opts ...PageOption) {
+	if maxCharsPerPage <= 0 || maxCharsPerPage > embedDescriptionLimit {
+		maxCharsPerPage = embedDescriptionLimit
+	}
+	for _, chunk := range splitText(text, maxCharsPerPage) {
+		page := newPage(opts)
+		page.Description = chunk
+		p.Add(page)
+	}
+}
+
+// AddPaginatedItems adds embed pages listing perPage items each,
+// separated by newlines.
+//    items  : items to list
+//    perPage: amount of items on each page
+//             (if set to 0 or less, it defaults to 10)
+//    opts   : options of the pages, e.g. WithTemplate
+func (p *Paginator) AddPaginatedItems(items []string, perPage int, opts ...PageOption) {
+	if perPage <= 0 {
+		perPage = 10
+	}
+	for start := 0; start < len(items); start += perPage {
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+		page := newPage(opts)
+		page.Description = strings.Join(items[start:end], "\n")
+		p.Add(page)
+	}
+}
+
+// PaginateFields distributes fields across pages that copy the base embed's
+// styling and adds them to the paginator.
+//    base   : embed whose title, colour, thumbnail etc. are used for each page
+//    fields : fields to distribute
+//    perPage: amount of fields on each page
+//             (if set to 0 or less, or above 25, it defaults to 25)
+//    opts   : options of the pages, e.g. WithTemplate when base is nil
+func (p *Paginator) PaginateFields(base *discordgo.MessageEmbed, fields []*discordgo.MessageEmbedField, perPage int, opts ...PageOption) {
+	if perPage <= 0 || perPage > embedFieldLimit {
+		perPage = embedFieldLimit
+	}
+	if base == nil {
+		base = newPage(opts)
+	}
+	for start := 0; start < len(fields); start += perPage {
+		end := start + perPage
+		if end > len(fields) {
+			end = len(fields)
+		}
+		page := copyEmbed(base)
+		page.Fields = append([]*discordgo.MessageEmbedField{}, fields[start:end]...)
+		p.Add(page)
+	}
+}
+
+// SplitField returns value as fields of at most 1024 characters each,
+// split without breaking words. The first field is named name and
+// the continuation fields are named with a zero-width space to show no name.
+//    name : name of the first field
+//    value: value to split
+func SplitField(name, value string) []*discordgo.MessageEmbedField {
+	chunks := splitText(value, embedFieldValueLimit)
+	if len(chunks) == 0 {
+		return []*discordgo.MessageEmbedField{{Name: name, Value: value}}
+	}
+	fields := make([]*discordgo.MessageEmbedField, len(chunks))
+	for i, chunk := range chunks {
+		fields[i] = &discordgo.MessageEmbedField{
+			Name:  "\u200b",
+			Value: chunk,
+		}
+	}
+	fields[0].Name = name
+	return fields
+}
+
+// AddFromSlice adds embed pages listing perPage items each as a
+// numbered list, formatting every item with format. Pages that
+// would exceed the description limit are split further.
+//    p      : paginator to add the pages to
+//    items  : items to list
+//    perPage: amount of items on each page
+//             (if set to 0 or less, it defaults to 10)
+//    title  : title of every page
+//    format : function returning the text of an item
+//    opts   : options of the pages, e.g. WithTemplate
+func AddFromSlice[T any](p *Paginator, items []T, perPage int, title string, format func(T) string, opts ...PageOption) {
+	if perPage <= 0 {
+		perPage = 10
+	}
+	for start := 0; start < len(items); start += perPage {
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+		lines := make([]string, 0, end-start)
+		for i, item := range items[start:end] {
+			lines = append(lines, strconv.Itoa(start+i+1)+". "+format(item))
+		}
+		for _, description := range joinLines(lines, embedDescriptionLimit) {
+			page := newPage(opts)
+			page.Title = title
+			page.Description = description
+			p.Add(page)
+		}
+	}
+}
+
+// joinLines joins lines with newlines into chunks of at most maxLen
+// runes, splitting lines that are too long on their own.
+func joinLines(lines []string, maxLen int) []string {
+	var chunks []string
+	var current []string
+	length := 0
+	for _, line := range lines {
+		for _, part := range splitText(line, maxLen) {
+			n := utf8.RuneCountInString(part)
+			if len(current) > 0 && length+1+n > maxLen {
+				chunks = append(chunks, strings.Join(current, "\n"))
+				current, length = nil, 0
+			}
+			if len(current) > 0 {
+				length++
+			}
+			current = append(current, part)
+			length += n
+		}
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n"))
+	}
+	return chunks
+}
+
+// NewImageGallery returns a paginator showing one image per page
+//    ses      : discordgo session
+//    channelID: channelID to spawn the paginator on
+//    imageURLs: URLs of the images to show
+func NewImageGallery(ses Sessioner, channelID string, imageURLs []string) *Paginator {
+	return NewCaptionedImageGallery(ses, channelID, "", imageURLs, nil)
+}
+
+// NewCaptionedImageGallery returns a paginator showing one image per
+// page with a shared title and a caption for each image.
+//    ses      : discordgo session
+//    channelID: channelID to spawn the paginator on
+//    title    : title of every page
+//    imageURLs: URLs of the images to show
+//    captions : description of the image at the same index,
+//               images without a caption are left without one
+func NewCaptionedImageGallery(ses Sessioner, channelID, title string, imageURLs, captions []string) *Paginator {
+	p := NewPaginator(ses, channelID)
+	for i, url := range imageURLs {
+		page := &discordgo.MessageEmbed{
+			Title: title,
+			Image: &discordgo.MessageEmbedImage{URL: url},
+		}
+		if i < len(captions) {
+			page.Description = captions[i]
+		}
+		p.Add(page)
+	}
+	p.SetPageFooters()
+	return p
+}
@@ -0,0 +1,53 @@
+package dgwidgets
+
+import (
+	"sync"
+)
+
+// EnablePerChannelSerialization makes widgets send, edit and delete
+// their messages one at a time per channel, so bursts of widgets in
+// the same channel share its rate limit evenly instead of contending
+// for it. Every request waits for the ones before it in the channel,
+// adding latency in busy channels. Set it before spawning widgets.
+var EnablePerChannelSerialization bool
+
+// channelLock is the lock of a channel and the amount of its users
+type channelLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// channelLocks are the locks of the channels in use by ID
+var channelLocks = struct {
+	sync.Mutex
+	m map[string]*channelLock
+}{m: map[string]*channelLock{}}
+
+// lockChannel locks the channel when EnablePerChannelSerialization is
+// set and returns the function that unlocks it. The lock is removed
+// once no widget uses it.
+func lockChannel(channelID string) func() {
+	if !EnablePerChannelSerialization {
+		return func() {}
+	}
+
+	channelLocks.Lock()
+	l, ok := channelLocks.m[channelID]
+	if !ok {
+		l = &channelLock{}
+		channelLocks.m[channelID] = l
+	}
+	l.refs++
+	channelLocks.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		channelLocks.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(channelLocks.m, channelID)
+		}
+		channelLocks.Unlock()
+	}
+}
@@ -0,0 +1,140 @@
+package dgwidgets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// component custom ID constants
+const (
+	ComponentPrefix      = "dgwidgets:"
+	ComponentBeginning   = ComponentPrefix + "beginning"
+	ComponentPrevious    = ComponentPrefix + "previous"
+	ComponentNext        = ComponentPrefix + "next"
+	ComponentEnd         = ComponentPrefix + "end"
+	ComponentNumbers     = ComponentPrefix + "numbers"
+	ComponentSearch      = ComponentPrefix + "search"
+	ComponentStop        = ComponentPrefix + "stop"
+	ComponentSelect      = ComponentPrefix + "select"
+	ComponentFastBack    = ComponentPrefix + "fastback"
+	ComponentFastForward = ComponentPrefix + "fastforward"
+	ComponentSection     = ComponentPrefix + "section"
+	ComponentJumpModal   = ComponentPrefix + "jumpmodal"
+	ComponentJumpInput   = ComponentPrefix + "jumpinput"
+)
+
+// selectMenuLimit is the maximum amount of options in a select menu
+const selectMenuLimit = 25
+
+// navButton returns a button with the given emoji and custom ID
+func navButton(emoji, customID string) discordgo.Button {
+	return discordgo.Button{
+		Style:    discordgo.SecondaryButton,
+		Emoji:    componentEmoji(emoji),
+		CustomID: customID,
+	}
+}
+
+// selectMenu returns a select menu listing the pages around the one at index
+func (p *Paginator) selectMenu(index int) discordgo.SelectMenu {
+	total := p.PageCount()
+	start := index - selectMenuLimit/2
+	if start > total-selectMenuLimit {
+		start = total - selectMenuLimit
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + selectMenuLimit
+	if end > total {
+		end = total
+	}
+
+	options := make([]discordgo.SelectMenuOption, 0, end-start)
+	for i := start; i < end; i++ {
+		options = append(options, discordgo.SelectMenuOption{
+			Label:   fmt.Sprintf("Page %d", i+1),
+			Value:   strconv.Itoa(i),
+			Default: i == index,
+		})
+	}
+	return discordgo.SelectMenu{
+		MenuType:    discordgo.StringSelectMenu,
+		CustomID:    ComponentSelect,
+		Placeholder: "Jump to page",
+		Options:     options,
+	}
+}
+
+// messageComponents returns the components of the paginator's message
+// on the page at index, the page's components followed by the navigation
+// in components mode and only the navigation otherwise
+func (p *Paginator) messageComponents(index int) []discordgo.MessageComponent {
+	if !p.componentsMode() {
+		return p.navComponents(index)
+	}
+	components := append([]discordgo.MessageComponent{}, p.PageBuilder(index)...)
+	return append(components, p.navComponents(index)...)
+}
+
+// navComponents returns the navigation components of the paginator on the page at index
+func (p *Paginator) navComponents(index int) []discordgo.MessageComponent {
+	components := []discordgo.MessageComponent{}
+	if p.UseButtons {
+		components = append(components, p.navButtons(index)...)
+	}
+	if p.UseSelectMenu && p.PageCount() > 0 {
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{p.selectMenu(index)},
+		})
+	}
+	return components
+}
+
+// navButtons returns the rows of navigation buttons of the paginator on the page at index
+func (p *Paginator) navButtons(index int) []discordgo.MessageComponent {
+	nav := p.navEmojis()
+	if !p.EnableNumberJump {
+		nav.Numbers = ""
+	}
+	buttons := []discordgo.MessageComponent{}
+	for _, control := range []struct{ emoji, customID string }{
+		{nav.Beginning, ComponentBeginning},
+		{nav.Left, ComponentPrevious},
+		{nav.Right, ComponentNext},
+		{nav.End, ComponentEnd},
+		{nav.Numbers, ComponentNumbers},
+	} {
+		if control.emoji != "" && p.controlAvailable(index, control.customID) {
+			buttons = append(buttons, navButton(control.emoji, control.customID))
+		}
+	}
+
+	components := []discordgo.MessageComponent{}
+	if len(buttons) > 0 {
+		components = append(components, discordgo.ActionsRow{Components: buttons})
+	}
+
+	extra := []discordgo.MessageComponent{}
+	if p.EnableFastJump {
+		extra = append(extra,
+			navButton(NavFastBack, ComponentFastBack),
+			navButton(NavFastForward, ComponentFastForward),
+		)
+	}
+	if p.hasSections() {
+		extra = append(extra, navButton(NavSection, ComponentSection))
+	}
+	if p.EnableSearch {
+		extra = append(extra, navButton(NavSearch, ComponentSearch))
+	}
+	if p.EnableStopButton {
+		extra = append(extra, navButton(NavStop, ComponentStop))
+	}
+	if len(extra) > 0 {
+		components = append(components, discordgo.ActionsRow{Components: extra})
+	}
+	return components
+}
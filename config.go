@@ -0,0 +1,164 @@
+package dgwidgets
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Duration is a time.Duration that is written to JSON as a string like
+// "1m30s". Numbers are read as nanoseconds like time.Duration.
+type Duration time.Duration
+
+// MarshalJSON returns the duration as a JSON string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON reads a duration string or an amount of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		*d = Duration(n)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// PaginatorConfig is the behaviour and styling of a paginator without
+// its pages, e.g. to define paginators in configuration files.
+type PaginatorConfig struct {
+	Loop                    bool `json:"loop,omitempty"`
+	ClampGoto               bool `json:"clamp_goto,omitempty"`
+	DeleteMessageWhenDone   bool `json:"delete_message_when_done,omitempty"`
+	DeleteReactionsWhenDone bool `json:"delete_reactions_when_done,omitempty"`
+	// Defaults to -1, which keeps the colour, when nil
+	ColourWhenDone         *int `json:"colour_when_done,omitempty"`
+	ColourAllPagesWhenDone bool `json:"colour_all_pages_when_done,omitempty"`
+
+	UseButtons              bool `json:"use_buttons,omitempty"`
+	UseSelectMenu           bool `json:"use_select_menu,omitempty"`
+	HideUnavailableControls bool `json:"hide_unavailable_controls,omitempty"`
+	EnableSearch            bool `json:"enable_search,omitempty"`
+	EnableStopButton        bool `json:"enable_stop_button,omitempty"`
+	EnableFastJump          bool `json:"enable_fast_jump,omitempty"`
+	JumpStep                int  `json:"jump_step,omitempty"`
+	// Defaults to true when nil
+	EnableNumberJump *bool      `json:"enable_number_jump,omitempty"`
+	NumberJumpPrompt string     `json:"number_jump_prompt,omitempty"`
+	NavEmojis        *NavEmojis `json:"nav_emojis,omitempty"`
+
+	AutoPageFooter  bool   `json:"auto_page_footer,omitempty"`
+	ShowProgressBar bool   `json:"show_progress_bar,omitempty"`
+	ShowLoopHint    bool   `json:"show_loop_hint,omitempty"`
+	TitleTemplate   string `json:"title_template,omitempty"`
+	StaticContent   string `json:"static_content,omitempty"`
+
+	RemoveUnauthorizedReactions bool `json:"remove_unauthorized_reactions,omitempty"`
+
+	// Config falls back to the Widget's Timeout when the paginator's is zero
+	Timeout            Duration `json:"timeout,omitempty"`
+	IdleTimeout        Duration `json:"idle_timeout,omitempty"`
+	MaxLifetime        Duration `json:"max_lifetime,omitempty"`
+	RefreshAfterAction bool     `json:"refresh_after_action,omitempty"`
+}
+
+// NewPaginatorFromConfig returns a new Paginator configured with cfg
+//    ses      : discordgo session
+//    channelID: channelID to spawn the paginator on
+//    cfg      : configuration of the paginator
+func NewPaginatorFromConfig(ses Sessioner, channelID string, cfg PaginatorConfig) *Paginator {
+	p := NewPaginator(ses, channelID)
+	p.Loop = cfg.Loop
+	p.ClampGoto = cfg.ClampGoto
+	p.DeleteMessageWhenDone = cfg.DeleteMessageWhenDone
+	p.DeleteReactionsWhenDone = cfg.DeleteReactionsWhenDone
+	if cfg.ColourWhenDone != nil {
+		p.ColourWhenDone = *cfg.ColourWhenDone
+	}
+	p.ColourAllPagesWhenDone = cfg.ColourAllPagesWhenDone
+
+	p.UseButtons = cfg.UseButtons
+	p.UseSelectMenu = cfg.UseSelectMenu
+	p.HideUnavailableControls = cfg.HideUnavailableControls
+	p.EnableSearch = cfg.EnableSearch
+	p.EnableStopButton = cfg.EnableStopButton
+	p.EnableFastJump = cfg.EnableFastJump
+	p.JumpStep = cfg.JumpStep
+	if cfg.EnableNumberJump != nil {
+		p.EnableNumberJump = *cfg.EnableNumberJump
+	}
+	p.NumberJumpPrompt = cfg.NumberJumpPrompt
+	if cfg.NavEmojis != nil {
+		nav := *cfg.NavEmojis
+		p.NavEmojis = &nav
+	}
+
+	p.AutoPageFooter = cfg.AutoPageFooter
+	p.ShowProgressBar = cfg.ShowProgressBar
+	p.ShowLoopHint = cfg.ShowLoopHint
+	p.TitleTemplate = cfg.TitleTemplate
+	p.StaticContent = cfg.StaticContent
+
+	p.RemoveUnauthorizedReactions = cfg.RemoveUnauthorizedReactions
+
+	p.Timeout = time.Duration(cfg.Timeout)
+	p.IdleTimeout = time.Duration(cfg.IdleTimeout)
+	p.MaxLifetime = time.Duration(cfg.MaxLifetime)
+	p.RefreshAfterAction = cfg.RefreshAfterAction
+	return p
+}
+
+// Config returns the configuration of the paginator, e.g. to save it as JSON
+func (p *Paginator) Config() PaginatorConfig {
+	colour := p.ColourWhenDone
+	numberJump := p.EnableNumberJump
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = p.Widget.Timeout
+	}
+	cfg := PaginatorConfig{
+		Loop:                    p.Loop,
+		ClampGoto:               p.ClampGoto,
+		DeleteMessageWhenDone:   p.DeleteMessageWhenDone,
+		DeleteReactionsWhenDone: p.DeleteReactionsWhenDone,
+		ColourWhenDone:          &colour,
+		ColourAllPagesWhenDone:  p.ColourAllPagesWhenDone,
+
+		UseButtons:              p.UseButtons,
+		UseSelectMenu:           p.UseSelectMenu,
+		HideUnavailableControls: p.HideUnavailableControls,
+		EnableSearch:            p.EnableSearch,
+		EnableStopButton:        p.EnableStopButton,
+		EnableFastJump:          p.EnableFastJump,
+		JumpStep:                p.JumpStep,
+		EnableNumberJump:        &numberJump,
+		NumberJumpPrompt:        p.NumberJumpPrompt,
+
+		AutoPageFooter:  p.AutoPageFooter,
+		ShowProgressBar: p.ShowProgressBar,
+		ShowLoopHint:    p.ShowLoopHint,
+		TitleTemplate:   p.TitleTemplate,
+		StaticContent:   p.StaticContent,
+
+		RemoveUnauthorizedReactions: p.RemoveUnauthorizedReactions,
+
+		Timeout:            Duration(timeout),
+		IdleTimeout:        Duration(p.IdleTimeout),
+		MaxLifetime:        Duration(p.MaxLifetime),
+		RefreshAfterAction: p.RefreshAfterAction,
+	}
+	if p.NavEmojis != nil {
+		nav := *p.NavEmojis
+		cfg.NavEmojis = &nav
+	}
+	return cfg
+}
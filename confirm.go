@@ -0,0 +1,97 @@
+package dgwidgets
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Confirm is a yes/no prompt answered with reactions
+type Confirm struct {
+	*Widget
+
+	// Only allow this user to answer the prompt
+	AllowedUser string
+
+	mu     sync.Mutex
+	answer chan bool
+	cancel context.CancelFunc
+}
+
+// NewConfirm returns a new Confirm
+//    ses      : discordgo session
+//    channelID: channelID to spawn the prompt on
+//    embed    : embed asking the question
+func NewConfirm(ses Sessioner, channelID string, embed *discordgo.MessageEmbed) *Confirm {
+	c := &Confirm{
+		Widget: NewWidget(ses, channelID, embed),
+	}
+	c.Handle(NavConfirm, func(w *Widget, r *discordgo.MessageReaction) {
+		c.respond(true)
+	})
+	c.Handle(NavCancel, func(w *Widget, r *discordgo.MessageReaction) {
+		c.respond(false)
+	})
+
+	return c
+}
+
+// respond stores the answer and stops the prompt
+func (c *Confirm) respond(answer bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.answer == nil {
+		return
+	}
+	select {
+	case c.answer <- answer:
+	default:
+	}
+	c.cancel()
+}
+
+// Prompt spawns the prompt and blocks until it is answered.
+// Returns ErrConfirmTimeout when the widget times out and
+// the context's error when ctx is done before an answer.
+//    ctx: context to stop the prompt with
+func (c *Confirm) Prompt(ctx context.Context) (bool, error) {
+	spawnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answer := make(chan bool, 1)
+	c.mu.Lock()
+	c.answer = answer
+	c.cancel = cancel
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.answer = nil
+		c.mu.Unlock()
+	}()
+
+	if c.AllowedUser != "" {
+		c.UserWhitelist = []string{c.AllowedUser}
+	}
+
+	err := c.SpawnWithContext(spawnCtx)
+
+	// Clean up reactions unless the message is gone
+	if reason := c.LastStopReason(); c.Message != nil && reason != StopMessageDeleted && reason != StopChannelDeleted {
+		c.Ses.MessageReactionsRemoveAll(c.Message.ChannelID, c.Message.ID)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	select {
+	case a := <-answer:
+		return a, nil
+	default:
+	}
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	return false, ErrConfirmTimeout
+}
@@ -0,0 +1,452 @@
+// Package dgtest provides a fake Discord session to test widgets
+// without a Discord connection.
+package dgtest
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	dgwidgets "github.com/deveopinghitloa/widgets"
+)
+
+var _ dgwidgets.Sessioner = (*FakeSession)(nil)
+
+// Call is a recorded call to the fake session
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// handler is a registered event handler
+type handler struct {
+	fn        reflect.Value
+	eventType reflect.Type
+	once      bool
+}
+
+// FakeSession is a Sessioner that keeps messages and reactions in
+// memory, records every call and dispatches injected events to the
+// registered handlers.
+type FakeSession struct {
+	mu sync.Mutex
+
+	// Session is passed to event handlers, its State.User is the bot
+	Session *discordgo.Session
+	// Calls are the recorded calls in the order they were made
+	Calls []Call
+	// Messages are the messages that exist by ID
+	Messages map[string]*discordgo.Message
+	// Reactions are the bot's reactions on each message by ID
+	Reactions map[string][]string
+	// Emojis are the custom emojis of each guild by ID
+	Emojis map[string][]*discordgo.Emoji
+	// Errors are returned by the methods with the given names
+	// instead of performing the call, e.g. "MessageReactionsRemoveAll"
+	Errors map[string]error
+
+	handlers map[int]*handler
+	nextID   int
+}
+
+// NewFakeSession returns a new FakeSession with the given bot user ID
+//    botID: user ID of the bot
+func NewFakeSession(botID string) *FakeSession {
+	state := discordgo.NewState()
+	state.User = &discordgo.User{ID: botID, Bot: true}
+	return &FakeSession{
+		Session:   &discordgo.Session{State: state},
+		Messages:  map[string]*discordgo.Message{},
+		Reactions: map[string][]string{},
+		Emojis:    map[string][]*discordgo.Emoji{},
+		Errors:    map[string]error{},
+		handlers:  map[int]*handler{},
+	}
+}
+
+// record records a call and returns the error configured for the method
+func (f *FakeSession) record(method string, args ...interface{}) error {
+	f.Calls = append(f.Calls, Call{Method: method, Args: args})
+	return f.Errors[method]
+}
+
+// newID returns a new unique ID
+func (f *FakeSession) newID() string {
+	f.nextID++
+	return strconv.Itoa(f.nextID)
+}
+
+// CallsTo returns the recorded calls of the given method
+//    method: name of the method, e.g. "MessageReactionAdd"
+func (f *FakeSession) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []Call
+	for _, call := range f.Calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Message returns a copy of the message with the given ID
+//    messageID: ID of the message
+func (f *FakeSession) Message(messageID string) (*discordgo.Message, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	msg, ok := f.Messages[messageID]
+	if !ok {
+		return nil, false
+	}
+	c := *msg
+	return &c, true
+}
+
+// MessageReactions returns the bot's reactions on the message
+//    messageID: ID of the message
+func (f *FakeSession) MessageReactions(messageID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.Reactions[messageID]...)
+}
+
+// addHandler registers an event handler like discordgo.Session.AddHandler
+func (f *FakeSession) addHandler(fn interface{}, once bool) func() {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 2 {
+		return func() {}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := f.nextID
+	f.handlers[id] = &handler{fn: v, eventType: t.In(1), once: once}
+	return func() {
+		f.mu.Lock()
+		delete(f.handlers, id)
+		f.mu.Unlock()
+	}
+}
+
+// AddHandler registers an event handler
+func (f *FakeSession) AddHandler(fn interface{}) func() {
+	return f.addHandler(fn, false)
+}
+
+// AddHandlerOnce registers an event handler that is removed after its first event
+func (f *FakeSession) AddHandlerOnce(fn interface{}) func() {
+	return f.addHandler(fn, true)
+}
+
+// Emit calls the handlers of the event with it, e.g. a
+// *discordgo.MessageReactionAdd, and waits for them to return.
+//    event: event to dispatch
+func (f *FakeSession) Emit(event interface{}) {
+	ev := reflect.ValueOf(event)
+
+	f.mu.Lock()
+	var handlers []*handler
+	for id, h := range f.handlers {
+		if ev.Type() == h.eventType || (h.eventType.Kind() == reflect.Interface && ev.Type().Implements(h.eventType)) {
+			handlers = append(handlers, h)
+			if h.once {
+				delete(f.handlers, id)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	s := reflect.ValueOf(f.Session)
+	for _, h := range handlers {
+		h.fn.Call([]reflect.Value{s, ev})
+	}
+}
+
+// React emits a MessageReactionAdd event of the user on the message
+//    channelID: ID of the message's channel
+//    messageID: ID of the message
+//    userID   : ID of the reacting user
+//    emoji    : unicode value of the emoji, or a custom
+//               emoji formatted as "name:id"
+func (f *FakeSession) React(channelID, messageID, userID, emoji string) {
+	e := discordgo.Emoji{Name: emoji}
+	if parts := strings.Split(emoji, ":"); len(parts) >= 2 {
+		e = discordgo.Emoji{Name: parts[len(parts)-2], ID: parts[len(parts)-1]}
+	}
+	f.Emit(&discordgo.MessageReactionAdd{
+		MessageReaction: &discordgo.MessageReaction{
+			UserID:    userID,
+			MessageID: messageID,
+			ChannelID: channelID,
+			Emoji:     e,
+		},
+	})
+}
+
+// ClickButton emits a component interaction of the user on the message
+//    channelID: ID of the message's channel
+//    messageID: ID of the message
+//    userID   : ID of the user clicking the button
+//    customID : custom ID of the button
+func (f *FakeSession) ClickButton(channelID, messageID, userID, customID string) {
+	f.mu.Lock()
+	id := f.newID()
+	f.mu.Unlock()
+	f.Emit(&discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        id,
+			Type:      discordgo.InteractionMessageComponent,
+			ChannelID: channelID,
+			Message:   &discordgo.Message{ID: messageID, ChannelID: channelID},
+			User:      &discordgo.User{ID: userID},
+			Data: discordgo.MessageComponentInteractionData{
+				CustomID:      customID,
+				ComponentType: discordgo.ButtonComponent,
+			},
+		},
+	})
+}
+
+// ChannelMessageSend sends a message with the content
+func (f *FakeSession) ChannelMessageSend(channelID string, content string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return f.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Content: content})
+}
+
+// ChannelMessageSendComplex sends a message
+func (f *FakeSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ChannelMessageSendComplex", channelID, data); err != nil {
+		return nil, err
+	}
+
+	msg := &discordgo.Message{
+		ID:         f.newID(),
+		ChannelID:  channelID,
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+		Flags:      data.Flags,
+		Author:     f.Session.State.User,
+	}
+	f.Messages[msg.ID] = msg
+	c := *msg
+	return &c, nil
+}
+
+// edit applies fn to the message with the given ID
+func (f *FakeSession) edit(messageID string, fn func(msg *discordgo.Message)) (*discordgo.Message, error) {
+	msg, ok := f.Messages[messageID]
+	if !ok {
+		return nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownMessage, Message: "Unknown Message"}}
+	}
+	fn(msg)
+	c := *msg
+	return &c, nil
+}
+
+// ChannelMessageEdit edits the content of a message
+func (f *FakeSession) ChannelMessageEdit(channelID, messageID, content string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ChannelMessageEdit", channelID, messageID, content); err != nil {
+		return nil, err
+	}
+	return f.edit(messageID, func(msg *discordgo.Message) {
+		msg.Content = content
+	})
+}
+
+// ChannelMessageEditEmbed edits the embed of a message
+func (f *FakeSession) ChannelMessageEditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ChannelMessageEditEmbed", channelID, messageID, embed); err != nil {
+		return nil, err
+	}
+	return f.edit(messageID, func(msg *discordgo.Message) {
+		msg.Embeds = []*discordgo.MessageEmbed{embed}
+	})
+}
+
+// ChannelMessageEditComplex edits a message
+func (f *FakeSession) ChannelMessageEditComplex(m *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ChannelMessageEditComplex", m); err != nil {
+		return nil, err
+	}
+	return f.edit(m.ID, func(msg *discordgo.Message) {
+		if m.Content != nil {
+			msg.Content = *m.Content
+		}
+		if m.Embeds != nil {
+			msg.Embeds = *m.Embeds
+		}
+		if m.Components != nil {
+			msg.Components = *m.Components
+		}
+	})
+}
+
+// ChannelMessageDelete deletes a message
+func (f *FakeSession) ChannelMessageDelete(channelID, messageID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("ChannelMessageDelete", channelID, messageID); err != nil {
+		return err
+	}
+	delete(f.Messages, messageID)
+	delete(f.Reactions, messageID)
+	return nil
+}
+
+// MessageReactionAdd adds the bot's reaction to a message
+func (f *FakeSession) MessageReactionAdd(channelID, messageID, emojiID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("MessageReactionAdd", channelID, messageID, emojiID); err != nil {
+		return err
+	}
+	for _, v := range f.Reactions[messageID] {
+		if v == emojiID {
+			return nil
+		}
+	}
+	f.Reactions[messageID] = append(f.Reactions[messageID], emojiID)
+	return nil
+}
+
+// MessageReactionRemove removes a user's reaction from a message.
+// Only the bot's reactions are kept track of.
+func (f *FakeSession) MessageReactionRemove(channelID, messageID, emojiID, userID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("MessageReactionRemove", channelID, messageID, emojiID, userID); err != nil {
+		return err
+	}
+	if userID != "@me" && userID != f.Session.State.User.ID {
+		return nil
+	}
+	reactions := f.Reactions[messageID]
+	for i, v := range reactions {
+		if v == emojiID {
+			f.Reactions[messageID] = append(reactions[:i], reactions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// MessageReactionsRemoveAll removes all reactions from a message
+func (f *FakeSession) MessageReactionsRemoveAll(channelID, messageID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("MessageReactionsRemoveAll", channelID, messageID); err != nil {
+		return err
+	}
+	delete(f.Reactions, messageID)
+	return nil
+}
+
+// GuildMemberRoleAdd records adding a role to a member
+func (f *FakeSession) GuildMemberRoleAdd(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.record("GuildMemberRoleAdd", guildID, userID, roleID)
+}
+
+// GuildMemberRoleRemove records removing a role from a member
+func (f *FakeSession) GuildMemberRoleRemove(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.record("GuildMemberRoleRemove", guildID, userID, roleID)
+}
+
+// GuildEmojis returns the emojis of the guild from f.Emojis
+func (f *FakeSession) GuildEmojis(guildID string, _ ...discordgo.RequestOption) ([]*discordgo.Emoji, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("GuildEmojis", guildID); err != nil {
+		return nil, err
+	}
+	return append([]*discordgo.Emoji(nil), f.Emojis[guildID]...), nil
+}
+
+// interactionMessageID returns the ID of the response message of an interaction
+func interactionMessageID(interaction *discordgo.Interaction) string {
+	return "interaction:" + interaction.ID
+}
+
+// InteractionRespond responds to an interaction, creating its
+// response message unless it acknowledges a component
+func (f *FakeSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("InteractionRespond", interaction, resp); err != nil {
+		return err
+	}
+
+	switch resp.Type {
+	case discordgo.InteractionResponseChannelMessageWithSource, discordgo.InteractionResponseDeferredChannelMessageWithSource:
+		msg := &discordgo.Message{
+			ID:        interactionMessageID(interaction),
+			ChannelID: interaction.ChannelID,
+			Author:    f.Session.State.User,
+		}
+		if resp.Data != nil {
+			msg.Content = resp.Data.Content
+			msg.Embeds = resp.Data.Embeds
+			msg.Components = resp.Data.Components
+			msg.Flags = resp.Data.Flags
+		}
+		f.Messages[msg.ID] = msg
+	case discordgo.InteractionResponseUpdateMessage:
+		if interaction.Message != nil && resp.Data != nil {
+			f.edit(interaction.Message.ID, func(msg *discordgo.Message) {
+				msg.Content = resp.Data.Content
+				msg.Embeds = resp.Data.Embeds
+				msg.Components = resp.Data.Components
+			})
+		}
+	}
+	return nil
+}
+
+// InteractionResponseEdit edits the response message of an interaction
+func (f *FakeSession) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("InteractionResponseEdit", interaction, newresp); err != nil {
+		return nil, err
+	}
+	return f.edit(interactionMessageID(interaction), func(msg *discordgo.Message) {
+		if newresp.Content != nil {
+			msg.Content = *newresp.Content
+		}
+		if newresp.Embeds != nil {
+			msg.Embeds = *newresp.Embeds
+		}
+		if newresp.Components != nil {
+			msg.Components = *newresp.Components
+		}
+	})
+}
+
+// InteractionResponseDelete deletes the response message of an interaction
+func (f *FakeSession) InteractionResponseDelete(interaction *discordgo.Interaction, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.record("InteractionResponseDelete", interaction); err != nil {
+		return err
+	}
+	delete(f.Messages, interactionMessageID(interaction))
+	return nil
+}
@@ -0,0 +1,155 @@
+package dgtest_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	dgwidgets "github.com/deveopinghitloa/widgets"
+	"github.com/deveopinghitloa/widgets/dgtest"
+)
+
+// waitFor fails the test if cond doesn't become true within a second
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// newPaginator returns a paginator on the session with pages titled "1" to "3"
+func newPaginator(ses *dgtest.FakeSession) *dgwidgets.Paginator {
+	p := dgwidgets.NewPaginator(ses, "channel")
+	p.Add(
+		&discordgo.MessageEmbed{Title: "1"},
+		&discordgo.MessageEmbed{Title: "2"},
+		&discordgo.MessageEmbed{Title: "3"},
+	)
+	p.Widget.ReactionAddDelay = 0
+	p.DeleteReactionsWhenDone = true
+	return p
+}
+
+// title returns the title of the message's embed, or "" if it has none
+func title(ses *dgtest.FakeSession, messageID string) string {
+	msg, ok := ses.Message(messageID)
+	if !ok || len(msg.Embeds) == 0 {
+		return ""
+	}
+	return msg.Embeds[0].Title
+}
+
+func TestReactionNavigation(t *testing.T) {
+	ses := dgtest.NewFakeSession("bot")
+	p := newPaginator(ses)
+	if err := p.SpawnAsync(); err != nil {
+		t.Fatalf("SpawnAsync: %v", err)
+	}
+	waitFor(t, "the message", func() bool { return p.Message() != nil })
+	msg := p.Message()
+
+	want := []string{dgwidgets.NavBeginning, dgwidgets.NavLeft, dgwidgets.NavRight, dgwidgets.NavEnd, dgwidgets.NavNumbers}
+	waitFor(t, "the reaction buttons", func() bool {
+		return len(ses.MessageReactions(msg.ID)) == len(want)
+	})
+	if got := ses.MessageReactions(msg.ID); !reflect.DeepEqual(got, want) {
+		t.Fatalf("reactions = %q, want %q", got, want)
+	}
+	if got := title(ses, msg.ID); got != "1" {
+		t.Fatalf("title = %q, want %q", got, "1")
+	}
+
+	for _, step := range []struct{ emoji, title string }{
+		{dgwidgets.NavRight, "2"},
+		{dgwidgets.NavRight, "3"},
+		{dgwidgets.NavLeft, "2"},
+		{dgwidgets.NavEnd, "3"},
+		{dgwidgets.NavBeginning, "1"},
+	} {
+		ses.React(msg.ChannelID, msg.ID, "user", step.emoji)
+		waitFor(t, "page "+step.title, func() bool { return title(ses, msg.ID) == step.title })
+	}
+	if got := p.CurrentIndex(); got != 0 {
+		t.Fatalf("CurrentIndex = %d, want 0", got)
+	}
+
+	// The user's reactions are removed after being handled
+	waitFor(t, "the user's reactions to be removed", func() bool {
+		return len(ses.CallsTo("MessageReactionRemove")) == 5
+	})
+	for _, call := range ses.CallsTo("MessageReactionRemove") {
+		if userID := call.Args[3]; userID != "user" {
+			t.Fatalf("removed reaction of %v, want user", userID)
+		}
+	}
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if reason, err := p.Wait(); err != nil || reason != dgwidgets.StopUser {
+		t.Fatalf("Wait = %v, %v, want StopUser", reason, err)
+	}
+	if got := ses.MessageReactions(msg.ID); len(got) != 0 {
+		t.Fatalf("reactions after stop = %q, want none", got)
+	}
+	if calls := ses.CallsTo("MessageReactionsRemoveAll"); len(calls) != 1 {
+		t.Fatalf("MessageReactionsRemoveAll called %d times, want 1", len(calls))
+	}
+}
+
+func TestButtonNavigation(t *testing.T) {
+	ses := dgtest.NewFakeSession("bot")
+	p := newPaginator(ses)
+	p.UseButtons = true
+	if err := p.SpawnAsync(); err != nil {
+		t.Fatalf("SpawnAsync: %v", err)
+	}
+	waitFor(t, "the message", func() bool { return p.Message() != nil })
+	msg := p.Message()
+
+	if sent, _ := ses.Message(msg.ID); len(sent.Components) == 0 {
+		t.Fatal("message has no navigation buttons")
+	}
+	if got := ses.MessageReactions(msg.ID); len(got) != 0 {
+		t.Fatalf("reactions = %q, want none with buttons", got)
+	}
+
+	for _, step := range []struct{ customID, title string }{
+		{dgwidgets.ComponentNext, "2"},
+		{dgwidgets.ComponentEnd, "3"},
+		{dgwidgets.ComponentPrevious, "2"},
+	} {
+		ses.ClickButton(msg.ChannelID, msg.ID, "user", step.customID)
+		waitFor(t, "page "+step.title, func() bool { return title(ses, msg.ID) == step.title })
+	}
+
+	// Every click is acknowledged without a new message
+	responses := ses.CallsTo("InteractionRespond")
+	if len(responses) != 3 {
+		t.Fatalf("InteractionRespond called %d times, want 3", len(responses))
+	}
+	for _, call := range responses {
+		resp := call.Args[1].(*discordgo.InteractionResponse)
+		if resp.Type != discordgo.InteractionResponseDeferredMessageUpdate {
+			t.Fatalf("interaction response type = %v, want DeferredMessageUpdate", resp.Type)
+		}
+	}
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if _, err := p.Wait(); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if done, _ := ses.Message(msg.ID); len(done.Components) != 0 {
+		t.Fatalf("message has %d components after stop, want none", len(done.Components))
+	}
+	if got := title(ses, msg.ID); got != "2" {
+		t.Fatalf("title after stop = %q, want %q", got, "2")
+	}
+}
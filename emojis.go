@@ -1,5 +1,11 @@
 package dgwidgets
 
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
 // emoji constants
 const (
 	NavPlus        = "➕"
@@ -15,4 +21,67 @@ const (
 	NavNumbers     = "🔢"
 	NavInformation = "ℹ"
 	NavSave        = "💾"
+	NavSearch      = "🔍"
+	NavConfirm     = "✅"
+	NavCancel      = "❌"
+	NavFastBack    = "⏮"
+	NavFastForward = "⏭"
+	NavSection     = "📑"
 )
+
+// NumberEmojis are the emojis for the numbers one to ten
+var NumberEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣", "🔟"}
+
+// FormatEmoji formats a custom emoji so it can be used as a handler
+// or navigation emoji.
+//    name    : name of the emoji
+//    id      : ID of the emoji
+//    animated: whether the emoji is animated
+func FormatEmoji(name, id string, animated bool) string {
+	if animated {
+		return "a:" + name + ":" + id
+	}
+	return name + ":" + id
+}
+
+// parseEmoji splits an emoji formatted with FormatEmoji into its parts.
+// Unicode emojis are returned as the name with an empty ID.
+func parseEmoji(emoji string) (name, id string, animated bool) {
+	parts := strings.Split(emoji, ":")
+	switch {
+	case len(parts) == 3 && parts[0] == "a":
+		return parts[1], parts[2], true
+	case len(parts) == 2:
+		return parts[0], parts[1], false
+	}
+	return emoji, "", false
+}
+
+// emojiMatches returns true if the reacted emoji is the given emoji.
+// Custom emojis are matched by ID.
+func emojiMatches(emoji string, reacted discordgo.Emoji) bool {
+	name, id, _ := parseEmoji(emoji)
+	if reacted.ID != "" {
+		return id == reacted.ID
+	}
+	return id == "" && name == reacted.Name
+}
+
+// reactionAPIName returns the emoji in the format used by the reaction endpoints
+func reactionAPIName(emoji string) string {
+	name, id, _ := parseEmoji(emoji)
+	if id == "" {
+		return name
+	}
+	return name + ":" + id
+}
+
+// componentEmoji returns the emoji for use in a message component
+func componentEmoji(emoji string) *discordgo.ComponentEmoji {
+	name, id, animated := parseEmoji(emoji)
+	return &discordgo.ComponentEmoji{
+		Name:     name,
+		ID:       id,
+		Animated: animated,
+	}
+}
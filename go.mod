@@ -1,7 +1,10 @@
 module github.com/deveopinghitloa/widgets
 
-go 1.15
+go 1.18
 
-require	(
-	github.com/bwmarrin/discordgo v0.22.0
+require github.com/bwmarrin/discordgo v0.29.0
+
+require (
+	github.com/gorilla/websocket v1.4.2 // indirect
+	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b // indirect
 )
@@ -0,0 +1,135 @@
+package dgwidgets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PaginatorManager keeps track of running paginators by message ID,
+// e.g. to stop a user's previous paginator when they run a new command.
+type PaginatorManager struct {
+	mu         sync.Mutex
+	paginators map[string]*Paginator
+}
+
+// NewPaginatorManager returns a new PaginatorManager
+func NewPaginatorManager() *PaginatorManager {
+	return &PaginatorManager{
+		paginators: map[string]*Paginator{},
+	}
+}
+
+// Register adds the paginator to the manager once its message is sent
+// and removes it when it stops. It wraps the paginator's OnStart and
+// OnStop hooks, so it should be called after they are set.
+//    p: paginator to register
+func (m *PaginatorManager) Register(p *Paginator) {
+	onStart, onStop := p.OnStart, p.OnStop
+	p.OnStart = func(p *Paginator) {
+		m.add(p)
+		if onStart != nil {
+			onStart(p)
+		}
+	}
+	p.OnStop = func(p *Paginator, reason StopReason) {
+		m.remove(p)
+		if onStop != nil {
+			onStop(p, reason)
+		}
+	}
+
+	// The paginator may already be running
+	if p.Running() {
+		m.add(p)
+	}
+}
+
+// add stores the paginator by the ID of its message
+func (m *PaginatorManager) add(p *Paginator) {
+	msg := p.Message()
+	if msg == nil {
+		return
+	}
+	m.mu.Lock()
+	m.paginators[msg.ID] = p
+	m.mu.Unlock()
+}
+
+// remove deletes the paginator from the manager
+func (m *PaginatorManager) remove(p *Paginator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, v := range m.paginators {
+		if v == p {
+			delete(m.paginators, id)
+		}
+	}
+}
+
+// Get returns the running paginator on the given message
+//    messageID: ID of the paginator's message
+func (m *PaginatorManager) Get(messageID string) (*Paginator, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.paginators[messageID]
+	return p, ok
+}
+
+// StopAll stops all running paginators of the manager
+func (m *PaginatorManager) StopAll() {
+	m.mu.Lock()
+	paginators := make([]*Paginator, 0, len(m.paginators))
+	for _, p := range m.paginators {
+		paginators = append(paginators, p)
+	}
+	m.mu.Unlock()
+
+	for _, p := range paginators {
+		p.Stop()
+	}
+}
+
+// PaginatorErrors are the errors of several paginators
+type PaginatorErrors []error
+
+// Error returns the errors joined with "; "
+func (e PaginatorErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// SendPaginators spawns the paginators at once and blocks until all
+// of them stopped. All of them are stopped once ctx is done.
+// Returns PaginatorErrors with the errors of the paginators that
+// failed to spawn or stopped with an error, or nil if none did.
+//    ctx       : context to stop the paginators with
+//    paginators: paginators to spawn
+func SendPaginators(ctx context.Context, paginators ...*Paginator) error {
+	var errs PaginatorErrors
+	spawned := make([]bool, len(paginators))
+	for i, p := range paginators {
+		if err := p.SpawnAsyncWithContext(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("paginator %d: %w", i, err))
+			continue
+		}
+		spawned[i] = true
+	}
+
+	for i, p := range paginators {
+		if !spawned[i] {
+			continue
+		}
+		if _, err := p.Wait(); err != nil {
+			errs = append(errs, fmt.Errorf("paginator %d: %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package dgwidgets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Menu lets a user pick one of a list of options with numbered reactions.
+// Options are paged ten at a time.
+type Menu struct {
+	*Paginator
+
+	Title   string
+	Options []string
+
+	// Only allow this user to pick an option
+	AllowedUser string
+
+	mu     sync.Mutex
+	answer chan int
+	cancel context.CancelFunc
+}
+
+// NewMenu returns a new Menu
+//    ses      : discordgo session
+//    channelID: channelID to spawn the menu on
+//    title    : title of the menu embed
+//    options  : options to pick from
+func NewMenu(ses Sessioner, channelID string, title string, options []string) *Menu {
+	m := &Menu{
+		Paginator: NewPaginator(ses, channelID),
+		Title:     title,
+		Options:   options,
+	}
+	m.DeleteReactionsWhenDone = true
+	// The number reactions pick options, not pages
+	m.EnableNumberJump = false
+
+	for i, emoji := range NumberEmojis {
+		n := i
+		m.Widget.Handle(emoji, func(w *Widget, r *discordgo.MessageReaction) {
+			if index := m.Index.get()*len(NumberEmojis) + n; index < len(m.Options) {
+				m.respond(index)
+			}
+		})
+	}
+
+	return m
+}
+
+// respond stores the picked index and stops the menu
+func (m *Menu) respond(index int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.answer == nil {
+		return
+	}
+	select {
+	case m.answer <- index:
+	default:
+	}
+	m.cancel()
+}
+
+// buildPages builds the menu pages from m.Options
+func (m *Menu) buildPages() {
+	m.Pages = []*discordgo.MessageEmbed{}
+	for start := 0; start < len(m.Options); start += len(NumberEmojis) {
+		var lines []string
+		for i := start; i < start+len(NumberEmojis) && i < len(m.Options); i++ {
+			lines = append(lines, fmt.Sprintf("%s %s", NumberEmojis[i-start], m.Options[i]))
+		}
+		m.Add(&discordgo.MessageEmbed{
+			Title:       m.Title,
+			Description: strings.Join(lines, "\n"),
+		})
+	}
+
+	// Navigation is only needed with more than one page
+	if len(m.Pages) == 1 {
+		m.NavEmojis = &NavEmojis{}
+	}
+}
+
+// Select spawns the menu and blocks until an option is picked.
+// Returns the index of the picked option, ErrMenuTimeout when
+// the widget times out and the context's error when ctx is
+// done before an option is picked.
+//    ctx: context to stop the menu with
+func (m *Menu) Select(ctx context.Context) (int, error) {
+	if len(m.Options) == 0 {
+		return -1, ErrNoOptions
+	}
+
+	spawnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	answer := make(chan int, 1)
+	m.mu.Lock()
+	m.answer = answer
+	m.cancel = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.answer = nil
+		m.mu.Unlock()
+	}()
+
+	if m.AllowedUser != "" {
+		m.RestrictToUser(m.AllowedUser)
+	}
+	m.buildPages()
+
+	if err := m.SpawnWithContext(spawnCtx); err != nil {
+		return -1, err
+	}
+
+	select {
+	case index := <-answer:
+		return index, nil
+	default:
+	}
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+	return -1, ErrMenuTimeout
+}
@@ -0,0 +1,59 @@
+package dgwidgets
+
+import (
+	"time"
+)
+
+// navigation directions reported to Metrics.IncNavigation
+const (
+	NavigationBeginning   = "beginning"
+	NavigationPrevious    = "previous"
+	NavigationNext        = "next"
+	NavigationEnd         = "end"
+	NavigationFastBack    = "fastback"
+	NavigationFastForward = "fastforward"
+	NavigationSection     = "section"
+	NavigationJump        = "jump"
+	NavigationSearch      = "search"
+	NavigationSelect      = "select"
+)
+
+// Metrics receives events of paginators, e.g. to export them to Prometheus
+type Metrics interface {
+	// IncSpawned is called when a paginator is spawned
+	IncSpawned()
+	// IncStopped is called when a paginator stops
+	IncStopped(reason StopReason)
+	// ObserveLifetime is called with how long a stopped paginator ran
+	ObserveLifetime(d time.Duration)
+	// IncNavigation is called when a user navigates with a control
+	IncNavigation(direction string)
+}
+
+// DefaultMetrics is used by paginators without Metrics, nil disables metrics
+var DefaultMetrics Metrics
+
+// noopMetrics is used when no metrics are set
+type noopMetrics struct{}
+
+func (noopMetrics) IncSpawned()                   {}
+func (noopMetrics) IncStopped(StopReason)         {}
+func (noopMetrics) ObserveLifetime(time.Duration) {}
+func (noopMetrics) IncNavigation(string)          {}
+
+// metrics returns p.Metrics, DefaultMetrics or a no-op implementation
+func (p *Paginator) metrics() Metrics {
+	if p.Metrics != nil {
+		return p.Metrics
+	}
+	if DefaultMetrics != nil {
+		return DefaultMetrics
+	}
+	return noopMetrics{}
+}
+
+// navigated reports the navigation and updates the message
+func (p *Paginator) navigated(direction string) {
+	p.metrics().IncNavigation(direction)
+	p.reportError(p.Update())
+}
@@ -0,0 +1,114 @@
+package dgwidgets
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// modalTextInput returns the value of the text input with the given custom ID
+func modalTextInput(data discordgo.ModalSubmitInteractionData, customID string) string {
+	for _, component := range data.Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, c := range row.Components {
+			if input, ok := c.(*discordgo.TextInput); ok && input.CustomID == customID {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
+
+// jumpModal returns the modal asking for the page to jump to.
+// Its custom ID is suffixed with the paginator's message ID so
+// the submission can be matched to the paginator.
+func (p *Paginator) jumpModal(messageID string) *discordgo.InteractionResponse {
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: ComponentJumpModal + ":" + messageID,
+			Title:    "Jump to page",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  ComponentJumpInput,
+							Label:     p.numberJumpPrompt(),
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MaxLength: 10,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// handleJumpModal opens the jump modal when the number jump button
+// is used and jumps to the submitted page number
+func (p *Paginator) handleJumpModal(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	msg := p.Message()
+	if msg == nil {
+		return
+	}
+	userID := interactionUserID(i.Interaction)
+
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		if i.Message == nil || i.Message.ID != msg.ID || i.MessageComponentData().CustomID != ComponentNumbers {
+			return
+		}
+		if !p.Widget.isUserAllowed(userID) {
+			p.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredMessageUpdate,
+			})
+			return
+		}
+		p.Widget.markActive()
+		err := p.Ses.InteractionRespond(i.Interaction, p.jumpModal(msg.ID))
+		p.reportError(wrapErr(err, "paginator: open jump modal for user %s", userID))
+
+	case discordgo.InteractionModalSubmit:
+		data := i.ModalSubmitData()
+		if !strings.HasPrefix(data.CustomID, ComponentJumpModal) || data.CustomID != ComponentJumpModal+":"+msg.ID {
+			return
+		}
+		if !p.Widget.isUserAllowed(userID) {
+			p.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredMessageUpdate,
+			})
+			return
+		}
+		p.Widget.markActive()
+
+		n, err := parsePageNumber(modalTextInput(data, ComponentJumpInput))
+		if err != nil && p.InvalidPageNumberMessage != "" {
+			err := p.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: p.InvalidPageNumberMessage,
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			})
+			p.reportError(wrapErr(err, "paginator: respond to jump modal of user %s", userID))
+			return
+		}
+
+		// Acknowledge the submission, the message is edited separately.
+		p.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+		if err != nil {
+			return
+		}
+		if err := p.Goto(n - 1); err != nil {
+			p.reportError(err)
+			return
+		}
+		p.navigated(NavigationJump)
+	}
+}
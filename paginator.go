@@ -1,8 +1,12 @@
 package dgwidgets
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"math/rand"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,193 +17,1960 @@ import (
 type Paginator struct {
 	sync.Mutex
 	Pages []*discordgo.MessageEmbed
-	Index Index
+	// Contents holds plain text pages. It is only used when Pages is empty.
+	Contents []string
+	// PageFiles are attached to the embed page at the same index,
+	// replacing the previous page's attachments on navigation.
+	// Since files are sent again on every visit, their readers
+	// should implement io.Seeker so they can be rewound.
+	PageFiles []*discordgo.File
+	Index     Index
+
+	// PageProvider lazily builds the page at the given index.
+	// When set, it is used instead of Pages and TotalPages
+	// must be set to the amount of pages it provides.
+	// It is called while the paginator is locked.
+	PageProvider func(index int) (*discordgo.MessageEmbed, error)
+	TotalPages   int
+
+	// PageBuilder returns the components of the page at the given
+	// index, rendering the pages as a components v2 message instead
+	// of embeds. The navigation components are appended to them.
+	// When set, TotalPages must be set to the amount of pages it builds.
+	PageBuilder func(index int) []discordgo.MessageComponent
 
 	// Loop back to the beginning or end when on the first or last page.
-	Loop   bool
+	Loop bool
+	// Make Goto go to the nearest page instead of returning
+	// ErrIndexOutOfBounds, e.g. so jumping to page 500 of the number
+	// jump control goes to the last page
+	ClampGoto bool
+
 	Widget *Widget
 
-	Ses *discordgo.Session
-	
+	Ses Sessioner
+
+	DeleteMessageWhenDone   bool
+	DeleteReactionsWhenDone bool
+	ColourWhenDone          int
+	// Apply ColourWhenDone to every page instead of only the current one
+	ColourAllPagesWhenDone bool
+
+	// Remove only the bot's own reactions when removing all reactions
+	// isn't permitted, e.g. in DMs or without Manage Messages.
+	// Defaults to true.
+	RemoveOwnReactionsFallback bool
+
+	// Use message buttons for navigation instead of reactions.
+	// When DeleteReactionsWhenDone is set the buttons are removed when done.
+	UseButtons bool
+	// Add a select menu to jump directly to a page. With more than 25
+	// pages it lists the pages around the current one.
+	UseSelectMenu bool
+	// Give every user their own page index: using a navigation button
+	// responds with an ephemeral view of the pages the user navigates
+	// independently instead of changing the shared message. Requires
+	// UseButtons. Views are forgotten after being unused for 15 minutes,
+	// when the interaction tokens expire, and when the paginator stops.
+	PerUserViews bool
+
+	// Repost the paginator at the bottom of the channel once
+	// StickyThreshold messages were sent below it, at most once
+	// every StickyInterval. The current page is kept.
+	Sticky bool
+	// Messages sent below a sticky paginator before it is reposted,
+	// defaults to 5 when zero
+	StickyThreshold int
+	// Minimum time between reposts of a sticky paginator,
+	// defaults to 30 seconds when zero
+	StickyInterval time.Duration
+
+	// Only allow listed users to control the paginator.
+	// When set, it replaces the Widget's UserWhitelist on Spawn.
+	AllowedUsers []string
+	// Remove reactions of users that aren't allowed to control the paginator
+	RemoveUnauthorizedReactions bool
+	// Only let presenters control the paginator and remove
+	// everyone else's reactions, e.g. for presentations
+	ViewerMode *ViewerMode
+	// Remove reactions that aren't controls of the paginator
+	StripForeignReactions bool
+
+	// How long the number jump and search controls wait for
+	// the user's input, defaults to 10 seconds when zero
+	QueryInputTimeout time.Duration
+	// Delete the user's input message after reading it
+	DeleteQueryInput bool
+
+	// Add the number jump control, which reads a page number from the
+	// user's next message and needs the Message Content intent.
+	// Defaults to true.
+	EnableNumberJump bool
+	// Prompt sent by the number jump control, defaults to
+	// "Insert a page number to go to" when empty
+	NumberJumpPrompt string
+	// Message sent when the number jump input isn't a number,
+	// deleted again after a few seconds. Nothing is sent when empty.
+	InvalidPageNumberMessage string
+	// Ask for the page number of the number jump button with a modal
+	// instead of reading the user's next message, so the Message
+	// Content intent isn't needed. Requires UseButtons.
+	JumpViaModal bool
+	// Add controls that jump back and forward by JumpStep pages
+	EnableFastJump bool
+	// Amount of pages the fast jump controls jump by, defaults to 10 when zero
+	JumpStep int
+	// Add a control that stops the paginator
+	EnableStopButton bool
+	// Emojis of the navigation controls, defaults to DefaultNavEmojis when nil
+	NavEmojis *NavEmojis
+	// Emojis of the controls and handlers in the order their reactions
+	// are added. Unlisted ones are added after them in the default order.
+	ControlOrder []string
+	// Add a search control that jumps to the first page containing the query
+	EnableSearch bool
+	// Hide the controls that can't be used on the current page, e.g.
+	// the left and beginning controls on the first page, unless Loop is set
+	HideUnavailableControls bool
+
+	// Errors receives errors that occur while handling controls and
+	// cleaning up. Sends don't block, so errors are dropped when the
+	// channel isn't ready. It is the caller's responsibility to drain it.
+	Errors chan error
+
+	// Stop after this much time since the paginator was sent.
+	// When set, it replaces the Widget's Timeout on Spawn.
+	Timeout time.Duration
+	// Restart the Timeout after every navigation.
+	// When set, it replaces the Widget's RefreshAfterAction on Spawn.
+	RefreshAfterAction bool
+	// Stop after this much time without navigation.
+	// When set, it replaces the Widget's IdleTimeout on Spawn and
+	// the Widget's Timeout remains the maximum total lifetime.
+	IdleTimeout time.Duration
+	// Stop after this much time since the paginator was sent,
+	// regardless of activity. When set, it replaces the Widget's
+	// MaxLifetime on Spawn.
+	MaxLifetime time.Duration
+
+	// Text sent right away while the first page is built, e.g.
+	// "Loading…" for slow PageProviders. The message is then edited
+	// to show the first page. Nothing is sent first when empty.
+	LoadingContent string
+
+	// Text shown above the embed pages, kept on every update
+	StaticContent string
+
+	// Set the footer of the displayed page to its live position,
+	// e.g. "Page 2/5", whenever the message is rendered
+	AutoPageFooter bool
+	// Show a progress bar like "▬▬🔘▬▬ 3/6" in the footer of the displayed
+	// page when there is more than one page. It replaces AutoPageFooter.
+	ShowProgressBar bool
+	// Append " (loops)" to the page indicator of AutoPageFooter or
+	// ShowProgressBar on the first and last page when Loop is set
+	// and wraps around from the page, see SetLoopAnchors
+	ShowLoopHint bool
+	// Title of the displayed page, rendered on every update. The
+	// placeholders {page}, {total} and {title} are replaced with the
+	// page number, the amount of pages and the page's own title.
+	TitleTemplate string
+	// Width of the progress bar in characters, defaults to 10 when zero
+	ProgressBarWidth int
+	// Characters of the progress bar, defaults to DefaultProgressBarStyle when nil
+	ProgressBarStyle *ProgressBarStyle
+	// Show the name of the current section added with AddSection in the footer
+	ShowSectionInFooter bool
+
+	// Mentions allowed to ping when the paginator is sent, e.g. to keep
+	// search results from pinging the users they mention.
+	// When set, it replaces the Widget's AllowedMentions on Spawn.
+	AllowedMentions *discordgo.MessageAllowedMentions
+	// Message to send the paginator as a reply to, e.g. the command
+	// that invoked it. When set, it replaces the Widget's ReplyTo on Spawn.
+	ReplyTo *discordgo.MessageReference
+	// Ping the author of the ReplyTo message
+	MentionRepliedUser bool
+
+	// Check the pages with ValidatePages on Spawn and
+	// return the first error instead of sending them
+	ValidateOnSpawn bool
+	// Guild the custom navigation and handler emojis must be from.
+	// When set, Spawn returns ErrEmojiNotFound for emojis the guild
+	// doesn't have instead of sending the paginator.
+	EmojiGuildID string
+
+	// Coalesce updates requested within this interval into a single edit
+	MinUpdateInterval time.Duration
+	// How often an edit is retried after the Discord API rate limited
+	// it, waiting for its Retry-After. Defaults to 1 when zero,
+	// negative values disable retries.
+	RateLimitRetries int
+
+	// Metrics receives the paginator's events, defaults to DefaultMetrics when nil
+	Metrics Metrics
+
+	// Page to show first on Spawn, e.g. a position saved with
+	// PersistIndex. Indexes out of bounds fall back to the first page.
+	// When zero, the current index is kept.
+	StartIndex int
+	// PersistIndex is called with the new index whenever the current
+	// page index changes, e.g. to save the position to resume from.
+	PersistIndex func(index int)
+
+	// OnPageChange is called whenever the current page index changes.
+	OnPageChange func(p *Paginator, oldIndex, newIndex int)
+	// OnStart is called once the paginator's message has been sent.
+	OnStart func(p *Paginator)
+	// OnRenderDone is called with a copy of the current page when the
+	// paginator stops, after ColourWhenDone is applied, to style the
+	// final message, e.g. by marking it as expired. It isn't called
+	// in content and components mode or when the message is deleted.
+	OnRenderDone func(page *discordgo.MessageEmbed)
+	// OnStop is called when the paginator is cleaned up after stopping.
+	OnStop func(p *Paginator, reason StopReason)
+
+	running       bool
+	cancel        context.CancelFunc
+	stopRequested bool
+	handlersAdded bool
+	customKeys    []string
+	sections      []section
+	loopAnchors   map[int]bool
+	// footer returns the footer text of the page at index set by the
+	// last SetPageFooters call, kept to set it again when pages move.
+	// Content pages show it below their text when rendered.
+	footer func(index, total int, existing string) string
+	// footerBase is the footer text of each embed page before footers were set
+	footerBase map[*discordgo.MessageEmbed]string
+	controls   map[string]WidgetHandler
+	// keyOrder is the order of all the reactions, hidden controls are
+	// shown again in their place
+	keyOrder    []string
+	views       map[string]*view
+	updateTimer *time.Timer
+	done        chan struct{}
+	spawnErr    error
+
+	lastStopReason StopReason
+	pageCache      map[int]*discordgo.MessageEmbed
+}
+
+// NewPaginator returns a new Paginator
+//    ses      : discordgo session
+//    channelID: channelID to spawn the paginator on
+func NewPaginator(ses Sessioner, channelID string) *Paginator {
+	p := &Paginator{
+		Ses:                        ses,
+		Pages:                      []*discordgo.MessageEmbed{},
+		ColourWhenDone:             -1,
+		DeleteQueryInput:           true,
+		EnableNumberJump:           true,
+		RemoveOwnReactionsFallback: true,
+		Widget:                     NewWidget(ses, channelID, nil),
+	}
+
+	return p
+}
+
+// NavEmojis are the emojis used for the navigation controls.
+// An empty field means the control isn't added.
+type NavEmojis struct {
+	Beginning string `json:"beginning"`
+	Left      string `json:"left"`
+	Right     string `json:"right"`
+	End       string `json:"end"`
+	Numbers   string `json:"numbers"`
+}
+
+// DefaultNavEmojis returns the default navigation emojis
+func DefaultNavEmojis() NavEmojis {
+	return NavEmojis{
+		Beginning: NavBeginning,
+		Left:      NavLeft,
+		Right:     NavRight,
+		End:       NavEnd,
+		Numbers:   NavNumbers,
+	}
+}
+
+// navEmojis returns p.NavEmojis, or the defaults when it is nil
+func (p *Paginator) navEmojis() NavEmojis {
+	if p.NavEmojis == nil {
+		return DefaultNavEmojis()
+	}
+	return *p.NavEmojis
+}
+
+// queryInputOptions returns the options to query userID for input with
+func (p *Paginator) queryInputOptions(userID string) QueryInputOptions {
+	return QueryInputOptions{
+		UserID:         userID,
+		Timeout:        p.queryInputTimeout(),
+		DeleteResponse: p.DeleteQueryInput,
+	}
+}
+
+// queryInputTimeout returns p.QueryInputTimeout, or 10 seconds when it is zero
+func (p *Paginator) queryInputTimeout() time.Duration {
+	if p.QueryInputTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return p.QueryInputTimeout
+}
+
+// addHandlers registers the navigation controls on the widget.
+// The controls are added ahead of any handlers registered before Spawn,
+// custom handlers for a control's emoji take precedence.
+func (p *Paginator) addHandlers() {
+	if p.handlersAdded {
+		return
+	}
+	p.handlersAdded = true
+
+	custom := p.Widget.Keys
+	p.customKeys = custom
+	p.Widget.Keys = []string{}
+
+	nav := p.navEmojis()
+	p.addControl(nav.Beginning, ComponentBeginning, func(w *Widget, userID string) {
+		if err := p.GotoFirst(); err == nil {
+			p.navigated(NavigationBeginning)
+		}
+	})
+	p.addControl(nav.Left, ComponentPrevious, func(w *Widget, userID string) {
+		if err := p.PreviousPage(); err == nil {
+			p.navigated(NavigationPrevious)
+		}
+	})
+	p.addControl(nav.Right, ComponentNext, func(w *Widget, userID string) {
+		if err := p.NextPage(); err == nil {
+			p.navigated(NavigationNext)
+		}
+	})
+	p.addControl(nav.End, ComponentEnd, func(w *Widget, userID string) {
+		if err := p.GotoLast(); err == nil {
+			p.navigated(NavigationEnd)
+		}
+	})
+	if p.EnableFastJump {
+		p.addControl(NavFastBack, ComponentFastBack, func(w *Widget, userID string) {
+			if err := p.Jump(-p.jumpStep()); err == nil {
+				p.navigated(NavigationFastBack)
+			}
+		})
+		p.addControl(NavFastForward, ComponentFastForward, func(w *Widget, userID string) {
+			if err := p.Jump(p.jumpStep()); err == nil {
+				p.navigated(NavigationFastForward)
+			}
+		})
+	}
+	if p.hasSections() {
+		p.addControl(NavSection, ComponentSection, func(w *Widget, userID string) {
+			if err := p.NextSection(); err == nil {
+				p.navigated(NavigationSection)
+			}
+		})
+	}
+	if p.EnableNumberJump {
+		p.addControl(nav.Numbers, ComponentNumbers, func(w *Widget, userID string) {
+			if msg, err := w.QueryInputWithOptions(p.numberJumpPrompt(), p.queryInputOptions(userID)); err == nil {
+				n, err := parsePageNumber(msg.Content)
+				if err != nil {
+					p.invalidPageNumber(msg.ChannelID)
+					return
+				}
+				if err := p.Goto(n - 1); err != nil {
+					p.reportError(err)
+					return
+				}
+				p.navigated(NavigationJump)
+			}
+		})
+	}
+	if p.EnableSearch {
+		p.addControl(NavSearch, ComponentSearch, func(w *Widget, userID string) {
+			if msg, err := w.QueryInputWithOptions("Enter text to search for", p.queryInputOptions(userID)); err == nil {
+				if index, err := p.SearchPages(msg.Content); err == nil {
+					p.reportError(p.Goto(index))
+					p.navigated(NavigationSearch)
+				}
+			}
+		})
+	}
+
+	if p.UseSelectMenu {
+		p.Widget.HandleComponent(ComponentSelect, func(w *Widget, i *discordgo.InteractionCreate) {
+			values := i.MessageComponentData().Values
+			if len(values) == 0 {
+				return
+			}
+			if index, err := strconv.Atoi(values[0]); err == nil {
+				if err := p.Goto(index); err != nil {
+					p.reportError(err)
+					return
+				}
+				p.navigated(NavigationSelect)
+			}
+		})
+	}
+
+	if p.EnableStopButton {
+		p.addControl(NavStop, ComponentStop, func(w *Widget, userID string) {
+			p.Stop()
+		})
+	}
+
+	for _, key := range custom {
+		if !containsString(p.Widget.Keys, key) {
+			p.Widget.Keys = append(p.Widget.Keys, key)
+		}
+	}
+	p.Widget.Keys = orderKeys(p.Widget.Keys, p.ControlOrder)
+	p.keyOrder = append([]string(nil), p.Widget.Keys...)
+}
+
+// orderKeys returns keys with the keys listed in order first, in that
+// order, followed by the other keys in their original order
+func orderKeys(keys, order []string) []string {
+	if len(order) == 0 {
+		return keys
+	}
+	ordered := make([]string, 0, len(keys))
+	for _, key := range order {
+		if containsString(keys, key) && !containsString(ordered, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	for _, key := range keys {
+		if !containsString(ordered, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	return ordered
+}
+
+// addControl registers a navigation control as both a reaction
+// and a component handler. Controls without an emoji aren't added.
+//    emojiName: emoji of the reaction
+//    customID : custom ID of the button
+//    action   : function to call when the control is used
+func (p *Paginator) addControl(emojiName, customID string, action func(w *Widget, userID string)) {
+	if emojiName == "" {
+		return
+	}
+	handler := func(w *Widget, r *discordgo.MessageReaction) {
+		action(w, r.UserID)
+	}
+	if p.controls == nil {
+		p.controls = map[string]WidgetHandler{}
+	}
+	p.controls[customID] = handler
+	p.Widget.Handle(emojiName, handler)
+	if p.PerUserViews && isViewControl(customID) {
+		return
+	}
+	// The modal has to be the interaction's first response
+	if p.JumpViaModal && customID == ComponentNumbers {
+		return
+	}
+	p.Widget.HandleComponent(customID, func(w *Widget, i *discordgo.InteractionCreate) {
+		action(w, interactionUserID(i.Interaction))
+	})
+}
+
+// controlAvailable returns true if the control with the given
+// custom ID can be used on the page at index
+func (p *Paginator) controlAvailable(index int, customID string) bool {
+	if !p.HideUnavailableControls || (p.Loop && len(p.loopAnchors) == 0) {
+		return true
+	}
+	last := p.PageCount() - 1
+	switch customID {
+	case ComponentBeginning:
+		return index > 0
+	case ComponentPrevious:
+		return index > 0 || (p.Loop && p.canWrap(index, last))
+	case ComponentNext:
+		return index < last || (p.Loop && p.canWrap(index, 0))
+	case ComponentEnd:
+		return index < last
+	}
+	return true
+}
+
+// refreshControls shows and hides the navigation reactions
+// depending on whether they can be used on the current page.
+// The buttons are updated together with the page by updateOnce.
+func (p *Paginator) refreshControls() error {
+	if p.UseButtons || !p.HideUnavailableControls {
+		return nil
+	}
+
+	nav := p.navEmojis()
+	restore := map[string]WidgetHandler{}
+	for _, control := range []struct{ emoji, customID string }{
+		{nav.Beginning, ComponentBeginning},
+		{nav.Left, ComponentPrevious},
+		{nav.Right, ComponentNext},
+		{nav.End, ComponentEnd},
+	} {
+		if control.emoji == "" {
+			continue
+		}
+		shown := p.Widget.hasHandler(control.emoji)
+		available := p.controlAvailable(p.CurrentIndex(), control.customID)
+		if shown && !available {
+			if err := p.Widget.RemoveHandler(control.emoji); err != nil {
+				return err
+			}
+		} else if !shown && available {
+			restore[control.emoji] = p.controls[control.customID]
+		}
+	}
+	if len(restore) == 0 {
+		return nil
+	}
+	return p.Widget.restoreHandlers(restore, p.keyOrder)
+}
+
+// Spawn spawns the paginator in channel p.ChannelID
+func (p *Paginator) Spawn() error {
+	return p.SpawnWithContext(context.Background())
+}
+
+// SpawnWithContext spawns the paginator in channel p.ChannelID
+// and stops it once ctx is done, cleaning up as on timeout.
+//    ctx: context to stop the paginator with
+func (p *Paginator) SpawnWithContext(ctx context.Context) error {
+	return p.spawn(ctx, nil)
+}
+
+// AttachToMessage runs the paginator on an existing message in
+// p.Widget.ChannelID instead of sending a new one, e.g. to resume
+// a paginator restored with RestorePaginator after a restart.
+//    messageID: ID of the message to attach to
+func (p *Paginator) AttachToMessage(messageID string) error {
+	return p.spawn(context.Background(), &discordgo.Message{
+		ID:        messageID,
+		ChannelID: p.Widget.ChannelID,
+	})
+}
+
+// BindMessage makes Spawn take over an existing message, e.g. a
+// loading placeholder, editing it to the first page instead of
+// sending a new message. The channel is taken from msg.
+//    msg: message to bind the paginator to
+func (p *Paginator) BindMessage(msg *discordgo.Message) error {
+	return p.Widget.BindMessage(msg)
+}
+
+// SpawnAsync spawns the paginator in channel p.ChannelID without
+// blocking. Errors preventing the paginator from starting are
+// returned, call Wait to wait for it to stop.
+func (p *Paginator) SpawnAsync() error {
+	return p.SpawnAsyncWithContext(context.Background())
+}
+
+// SpawnAsyncWithContext spawns the paginator like SpawnAsync
+// and stops it once ctx is done.
+//    ctx: context to stop the paginator with
+func (p *Paginator) SpawnAsyncWithContext(ctx context.Context) error {
+	ctx, cancel, err := p.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	p.Lock()
+	p.done = done
+	p.Unlock()
+	go func() {
+		err := p.run(ctx, cancel, nil)
+		p.Lock()
+		p.spawnErr = err
+		p.Unlock()
+		close(done)
+	}()
+	return nil
+}
+
+// Wait blocks until the paginator spawned with SpawnAsync stops and
+// returns why it stopped and the error it stopped with.
+// Returns ErrNotRunning when it wasn't spawned with SpawnAsync.
+func (p *Paginator) Wait() (StopReason, error) {
+	p.Lock()
+	done := p.done
+	p.Unlock()
+	if done == nil {
+		return StopError, ErrNotRunning
+	}
+	<-done
+
+	p.Lock()
+	defer p.Unlock()
+	return p.lastStopReason, p.spawnErr
+}
+
+// LastStopReason returns why the paginator last stopped,
+// e.g. after Spawn returned
+func (p *Paginator) LastStopReason() StopReason {
+	p.Lock()
+	defer p.Unlock()
+	return p.lastStopReason
+}
+
+// spawn runs the paginator on a new message, or on attach when it is not nil
+func (p *Paginator) spawn(ctx context.Context, attach *discordgo.Message) error {
+	ctx, cancel, err := p.start(ctx)
+	if err != nil {
+		return err
+	}
+	return p.run(ctx, cancel, attach)
+}
+
+// start marks the paginator as running, returning the context
+// to run it with and the function that stops it
+func (p *Paginator) start(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	if p.ValidateOnSpawn {
+		if errs := p.ValidatePages(); len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+	}
+	if err := p.validateEmojis(); err != nil {
+		return nil, nil, err
+	}
+	if p.ViewerMode != nil && len(p.ViewerMode.Presenters) == 0 && len(p.AllowedUsers) == 0 {
+		return nil, nil, ErrNoPresenters
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if p.running {
+		return nil, nil, ErrAlreadyRunning
+	}
+	if p.pageCount() == 0 {
+		return nil, nil, ErrNoPages
+	}
+	if p.StartIndex != 0 {
+		if p.StartIndex > 0 && p.StartIndex < p.pageCount() {
+			p.Index.Set(p.StartIndex)
+		} else {
+			p.Index.Set(0)
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p.running = true
+	p.cancel = cancel
+	p.stopRequested = false
+	return ctx, cancel, nil
+}
+
+// run runs the started paginator until it stops
+func (p *Paginator) run(ctx context.Context, cancel context.CancelFunc, attach *discordgo.Message) (err error) {
+	defer cancel()
+
+	started := time.Now()
+	p.metrics().IncSpawned()
+
+	defer func() {
+		reason := p.Widget.LastStopReason()
+		p.Lock()
+		p.running = false
+		p.cancel = nil
+		if err != nil {
+			reason = StopError
+		} else if p.stopRequested && reason == StopContextCancelled {
+			reason = StopUser
+		}
+		p.lastStopReason = reason
+		p.Unlock()
+		p.cleanup(reason)
+
+		p.metrics().IncStopped(reason)
+		p.metrics().ObserveLifetime(time.Since(started))
+
+		if p.OnStop != nil {
+			p.OnStop(p, reason)
+		}
+	}()
+
+	// shown is set once the Widget's message shows the first page
+	var shown bool
+	if attach == nil && p.LoadingContent != "" {
+		placeholder, err := p.sendPlaceholder()
+		if err != nil {
+			return err
+		}
+		if placeholder != nil {
+			defer func() {
+				p.Widget.bound = nil
+				// Don't leave the placeholder behind when it was never edited
+				if !shown {
+					defer lockChannel(placeholder.ChannelID)()
+					p.Ses.ChannelMessageDelete(placeholder.ChannelID, placeholder.ID)
+				}
+			}()
+		}
+	}
+	if err := p.prepareMessage(); err != nil {
+		return err
+	}
+
+	p.addHandlers()
+
+	if len(p.AllowedUsers) > 0 {
+		p.Widget.UserWhitelist = p.AllowedUsers
+	}
+	if p.RemoveUnauthorizedReactions {
+		p.Widget.RemoveUnauthorizedReactions = true
+	}
+	if p.StripForeignReactions {
+		p.Widget.StripForeignReactions = true
+	}
+	p.applyViewerMode()
+	if p.Timeout != 0 {
+		p.Widget.Timeout = p.Timeout
+	}
+	if p.RefreshAfterAction {
+		p.Widget.RefreshAfterAction = true
+	}
+	if p.IdleTimeout != 0 {
+		p.Widget.IdleTimeout = p.IdleTimeout
+	}
+	if p.MaxLifetime != 0 {
+		p.Widget.MaxLifetime = p.MaxLifetime
+	}
+	if p.AllowedMentions != nil {
+		p.Widget.AllowedMentions = p.AllowedMentions
+	}
+	if p.ReplyTo != nil {
+		p.Widget.ReplyTo = p.ReplyTo
+	}
+	if p.MentionRepliedUser {
+		p.Widget.MentionRepliedUser = true
+	}
+
+	if p.UseButtons || p.UseSelectMenu || p.componentsMode() {
+		p.Widget.Components = p.messageComponents(p.CurrentIndex())
+	}
+	if p.UseButtons {
+		p.Widget.DisableReactions = true
+	}
+	if err := p.refreshControls(); err != nil {
+		return wrapErr(err, "paginator: refresh controls")
+	}
+
+	p.Widget.onStart = func() {
+		shown = true
+		if p.OnStart != nil {
+			p.OnStart(p)
+		}
+	}
+
+	if p.PerUserViews {
+		p.Lock()
+		p.views = map[string]*view{}
+		p.Unlock()
+		defer func() {
+			p.Lock()
+			p.views = nil
+			p.Unlock()
+		}()
+		defer p.Ses.AddHandler(p.handleViewInteraction)()
+	}
+
+	if p.JumpViaModal && p.UseButtons {
+		defer p.Ses.AddHandler(p.handleJumpModal)()
+	}
+
+	if p.Sticky && p.Widget.Interaction == nil {
+		defer p.watchSticky()()
+	}
+
+	if attach != nil {
+		return wrapErr(p.Widget.AttachWithContext(ctx, attach), "paginator: attach to message %s", attach.ID)
+	}
+	return wrapErr(p.Widget.SpawnWithContext(ctx), "paginator: spawn")
+}
+
+// renderDone edits the message with the current page styled by OnRenderDone
+func (p *Paginator) renderDone() error {
+	index := p.CurrentIndex()
+	page, err := p.renderPage()
+	if err != nil {
+		return wrapErr(err, "paginator: render page %d", index)
+	}
+	page = copyEmbed(page)
+	p.OnRenderDone(page)
+
+	if p.StaticContent != "" {
+		_, err = p.Widget.UpdateMessage(p.StaticContent, page)
+	} else {
+		_, err = p.Widget.UpdateEmbed(page)
+	}
+	return wrapErr(err, "paginator: render done page %d", index)
+}
+
+// sendPlaceholder sends LoadingContent and binds the Widget to it, so
+// the first page replaces it once it is built. Nothing is sent for
+// interactions and widgets that are already bound to a message.
+func (p *Paginator) sendPlaceholder() (*discordgo.Message, error) {
+	if p.Widget.Interaction != nil || p.Widget.bound != nil {
+		return nil, nil
+	}
+	reference := p.Widget.ReplyTo
+	if p.ReplyTo != nil {
+		reference = p.ReplyTo
+	}
+	unlock := lockChannel(p.Widget.ChannelID)
+	msg, err := p.Ses.ChannelMessageSendComplex(p.Widget.ChannelID, &discordgo.MessageSend{
+		Content:   p.LoadingContent,
+		Reference: reference,
+	})
+	unlock()
+	if err != nil {
+		return nil, wrapErr(err, "paginator: send loading message to channel %s", p.Widget.ChannelID)
+	}
+	p.Widget.bound = msg
+	return msg, nil
+}
+
+// prepareMessage sets the contents of the Widget's message to the current page
+func (p *Paginator) prepareMessage() error {
+	p.Widget.Flags = p.messageFlags()
+	if p.componentsMode() {
+		p.Widget.Embed = nil
+		p.Widget.Content = ""
+	} else if p.contentMode() {
+		content, err := p.PageContent()
+		if err != nil {
+			return wrapErr(err, "paginator: render page %d", p.CurrentIndex())
+		}
+		p.Widget.Content = content
+	} else {
+		page, err := p.renderPage()
+		if err != nil {
+			return wrapErr(err, "paginator: render page %d", p.CurrentIndex())
+		}
+		p.Widget.Embed = page
+		p.Widget.Content = p.StaticContent
+		if len(p.PageFiles) > 0 {
+			p.Widget.Files = p.pageFiles(p.CurrentIndex())
+		}
+	}
+	return nil
+}
+
+// messageFlags returns the flags of the Widget's message, which
+// are components v2 only while the pages are built as components
+func (p *Paginator) messageFlags() discordgo.MessageFlags {
+	if p.componentsMode() {
+		return p.Widget.Flags | discordgo.MessageFlagsIsComponentsV2
+	}
+	return p.Widget.Flags &^ discordgo.MessageFlagsIsComponentsV2
+}
+
+// cleanup deletes or edits the message once the paginator stopped
+func (p *Paginator) cleanup(reason StopReason) {
+	pending := p.stopUpdateTimer()
+
+	// The message is already gone when it or its channel was deleted
+	// externally, or was never sent when stopped before starting
+	if reason == StopMessageDeleted || reason == StopChannelDeleted || p.Widget.Message == nil {
+		return
+	}
+
+	// Delete Message when done
+	if p.DeleteMessageWhenDone && p.Widget.Message != nil {
+		p.reportError(p.Widget.DeleteMessage())
+	} else if (p.ColourWhenDone >= 0 || p.OnRenderDone != nil) && !p.contentMode() && !p.componentsMode() {
+		if p.ColourWhenDone >= 0 && p.ColourAllPagesWhenDone {
+			p.Lock()
+			for _, page := range p.Pages {
+				page.Color = p.ColourWhenDone
+			}
+			for _, page := range p.pageCache {
+				page.Color = p.ColourWhenDone
+			}
+			p.Unlock()
+		}
+		if page, err := p.Page(); err == nil {
+			if p.ColourWhenDone >= 0 {
+				page.Color = p.ColourWhenDone
+			}
+			if p.OnRenderDone != nil {
+				p.reportError(p.renderDone())
+			} else {
+				p.reportError(p.update())
+			}
+		}
+	} else if pending {
+		p.reportError(p.update())
+	}
+
+	// Delete reactions when done, unless the message was deleted
+	if p.DeleteReactionsWhenDone && p.Widget.Message != nil && !p.DeleteMessageWhenDone {
+		if p.componentsMode() {
+			// Keep the page, which is made of components
+			err := p.Widget.UpdateComponents(p.PageBuilder(p.CurrentIndex()))
+			p.reportError(wrapErr(err, "paginator: remove navigation components"))
+		} else if p.UseButtons || p.UseSelectMenu {
+			p.reportError(p.Widget.RemoveComponents())
+		}
+		if !p.UseButtons {
+			p.removeReactions()
+		}
+	}
+}
+
+// removeReactions removes all reactions from the message, falling
+// back to removing the bot's own reactions when it isn't permitted
+func (p *Paginator) removeReactions() {
+	msg := p.Widget.Message
+	err := p.Ses.MessageReactionsRemoveAll(msg.ChannelID, msg.ID)
+	p.reportError(wrapErr(err, "paginator: remove reactions from message %s", msg.ID))
+	if err == nil || !p.RemoveOwnReactionsFallback || !isForbidden(err) {
+		return
+	}
+	for _, key := range p.Widget.keys() {
+		err := p.Ses.MessageReactionRemove(msg.ChannelID, msg.ID, reactionAPIName(key), "@me")
+		p.reportError(wrapErr(err, "paginator: remove reaction %s from message %s", key, msg.ID))
+	}
+}
+
+// RestrictToUser only allows the given user to control the paginator
+//    userID: ID of the user
+func (p *Paginator) RestrictToUser(userID string) {
+	p.AllowedUsers = []string{userID}
+}
+
+// SpawnInteraction spawns the paginator as the response to an interaction,
+// such as a slash command, that hasn't been responded to yet.
+// Navigation uses buttons as reactions can't be added to ephemeral messages.
+//    i        : interaction to respond to
+//    ephemeral: only show the paginator to the user of the interaction
+func (p *Paginator) SpawnInteraction(i *discordgo.Interaction, ephemeral bool) error {
+	p.Widget.ChannelID = i.ChannelID
+	p.Widget.Interaction = i
+	p.Widget.Ephemeral = ephemeral
+	p.UseButtons = true
+	return p.Spawn()
+}
+
+// Stop stops the running paginator, cleaning up as on timeout.
+// Returns ErrNotRunning when the paginator isn't running.
+func (p *Paginator) Stop() error {
+	p.Lock()
+	defer p.Unlock()
+
+	if !p.running {
+		return ErrNotRunning
+	}
+	p.stopRequested = true
+	p.cancel()
+	return nil
+}
+
+// Add a page to the paginator
+// Pages can be added while the paginator is running, the message
+// isn't edited until the next Update.
+//    embed: embed page to add.
+func (p *Paginator) Add(embeds ...*discordgo.MessageEmbed) {
+	p.Lock()
+	p.Pages = append(p.Pages, embeds...)
+	p.Unlock()
+}
+
+// InsertPage inserts pages at index, moving the current index along
+// when the pages are inserted at or before it so the same page stays
+// shown. Inserting at the amount of pages appends them like Add.
+// PageFiles, sections, loop anchors and page footers follow the moved pages.
+//    index : index to insert the pages at
+//    embeds: pages to insert
+func (p *Paginator) InsertPage(index int, embeds ...*discordgo.MessageEmbed) error {
+	return p.changePage(func() error {
+		if index < 0 || index > len(p.Pages) || p.PageProvider != nil || p.contentMode() {
+			return ErrIndexOutOfBounds
+		}
+		count := len(p.Pages)
+		pages := make([]*discordgo.MessageEmbed, 0, len(p.Pages)+len(embeds))
+		pages = append(pages, p.Pages[:index]...)
+		pages = append(pages, embeds...)
+		p.Pages = append(pages, p.Pages[index:]...)
+
+		// Keep the files next to their pages, the inserted pages have none
+		if index < len(p.PageFiles) {
+			files := make([]*discordgo.File, 0, len(p.PageFiles)+len(embeds))
+			files = append(files, p.PageFiles[:index]...)
+			files = append(files, make([]*discordgo.File, len(embeds))...)
+			p.PageFiles = append(files, p.PageFiles[index:]...)
+		}
+		p.shiftPages(count, func(i int) int {
+			if i < index {
+				return i
+			}
+			return i + len(embeds)
+		})
+		p.applyFooters()
+
+		if current := p.Index.get(); index <= current && index < len(p.Pages)-len(embeds) {
+			p.Index.Set(current + len(embeds))
+		}
+		return nil
+	})
+}
+
+// RemovePage removes the page at index, keeping the current index
+// in range and updating the message if the removed page was shown.
+// Removing the last page leaves the paginator empty.
+// PageFiles, sections, loop anchors and page footers follow the moved pages.
+//    index: index of the page to remove
+func (p *Paginator) RemovePage(index int) error {
+	var visible bool
+	err := p.changePage(func() error {
+		if index < 0 || index >= len(p.Pages) || p.PageProvider != nil || p.contentMode() {
+			return ErrIndexOutOfBounds
+		}
+		count := len(p.Pages)
+		p.Pages = append(p.Pages[:index], p.Pages[index+1:]...)
+		if index < len(p.PageFiles) {
+			p.PageFiles = append(p.PageFiles[:index], p.PageFiles[index+1:]...)
+		}
+		p.shiftPages(count, func(i int) int {
+			if i == index {
+				return -1
+			} else if i > index {
+				return i - 1
+			}
+			return i
+		})
+		p.applyFooters()
+
+		current := p.Index.get()
+		visible = p.running && index == current && len(p.Pages) > 0
+		if index < current || current >= len(p.Pages) {
+			if current--; current < 0 {
+				current = 0
+			}
+			p.Index.Set(current)
+		}
+		return nil
+	})
+	if err != nil || !visible {
+		return err
+	}
+	return p.Update()
+}
+
+// SetPage replaces the page at index, updating the message
+// if the page is currently shown.
+//    index: index of the page to replace
+//    embed: new page
+func (p *Paginator) SetPage(index int, embed *discordgo.MessageEmbed) error {
+	p.Lock()
+	if index < 0 || index >= p.pageCount() || p.contentMode() {
+		p.Unlock()
+		return ErrIndexOutOfBounds
+	}
+	if p.PageProvider != nil {
+		if p.pageCache == nil {
+			p.pageCache = map[int]*discordgo.MessageEmbed{}
+		}
+		p.pageCache[index] = embed
+	} else {
+		p.Pages[index] = embed
+	}
+	visible := p.running && index == p.Index.get()
+	p.Unlock()
+
+	if visible {
+		return p.Update()
+	}
+	return nil
+}
+
+// Clear removes all pages and resets the index to the first page,
+// keeping the handlers and configuration so the paginator can be
+// reused. Pages must be added again before the next Spawn.
+// Returns ErrAlreadyRunning when the paginator is running.
+func (p *Paginator) Clear() error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.running {
+		return ErrAlreadyRunning
+	}
+	p.Pages = []*discordgo.MessageEmbed{}
+	p.Contents = nil
+	p.pageCache = nil
+	p.sections = nil
+	p.loopAnchors = nil
+	p.footer = nil
+	p.footerBase = nil
+	p.Index.Set(0)
+	return nil
+}
+
+// Reverse reverses the order of the pages and goes back to the first page.
+// Page footers set with SetPageFooters, SetPageFootersFormat or
+// SetPageFootersWithTimestamp are set again for the new positions,
+// loop anchors move along with their pages and sections are removed.
+// Returns ErrAlreadyRunning while the paginator is running.
+func (p *Paginator) Reverse() error {
+	return p.reorder(func(n int, swap func(i, j int)) {
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	})
+}
+
+// Shuffle shuffles the pages and goes back to the first page.
+// Page footers set with SetPageFooters, SetPageFootersFormat or
+// SetPageFootersWithTimestamp are set again for the new positions,
+// loop anchors move along with their pages and sections are removed.
+// Returns ErrAlreadyRunning while the paginator is running.
+//    seed: seed of the random order, the same seed gives the same order
+func (p *Paginator) Shuffle(seed int64) error {
+	r := rand.New(rand.NewSource(seed))
+	return p.reorder(r.Shuffle)
+}
+
+// reorder reorders the active pages and their files with order.
+// Loop anchors move along with their pages and sections are removed.
+func (p *Paginator) reorder(order func(n int, swap func(i, j int))) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.running {
+		return ErrAlreadyRunning
+	}
+	if p.contentMode() {
+		p.moveAnchors(len(p.Contents), order, func(i, j int) {
+			p.Contents[i], p.Contents[j] = p.Contents[j], p.Contents[i]
+		})
+		p.sections = nil
+		p.Index.Set(0)
+		return nil
+	}
+	if len(p.Pages) == 0 {
+		return ErrNoPages
+	}
+
+	// Keep the files next to their pages
+	if len(p.PageFiles) > 0 && len(p.PageFiles) < len(p.Pages) {
+		p.PageFiles = append(p.PageFiles, make([]*discordgo.File, len(p.Pages)-len(p.PageFiles))...)
+	}
+	p.moveAnchors(len(p.Pages), order, func(i, j int) {
+		p.Pages[i], p.Pages[j] = p.Pages[j], p.Pages[i]
+		if len(p.PageFiles) > 0 {
+			p.PageFiles[i], p.PageFiles[j] = p.PageFiles[j], p.PageFiles[i]
+		}
+	})
+	p.sections = nil
+	p.applyFooters()
+	p.Index.Set(0)
+	return nil
+}
+
+// moveAnchors reorders n pages with order and swap, moving
+// the loop anchors along with their pages while p is locked
+func (p *Paginator) moveAnchors(n int, order func(n int, swap func(i, j int)), swap func(i, j int)) {
+	moved := make([]int, n)
+	for i := range moved {
+		moved[i] = i
+	}
+	order(n, func(i, j int) {
+		swap(i, j)
+		moved[i], moved[j] = moved[j], moved[i]
+	})
+	if len(p.loopAnchors) == 0 {
+		return
+	}
+	anchors := map[int]bool{}
+	for i, old := range moved {
+		if p.loopAnchors[old] {
+			anchors[i] = true
+		}
+	}
+	p.loopAnchors = anchors
+}
+
+// shiftPages moves the sections and loop anchors along with their
+// pages after pages were inserted or removed while p is locked.
+// Sections and anchors of removed pages move to the next remaining
+// page, unless another section starts there.
+//    count   : amount of pages before the change
+//    newIndex: returns the new index of the page at index,
+//              or -1 if it was removed
+func (p *Paginator) shiftPages(count int, newIndex func(index int) int) {
+	var sections []section
+	for _, s := range p.sections {
+		start := -1
+		for i := s.start; i < count && start < 0; i++ {
+			start = newIndex(i)
+		}
+		if start < 0 {
+			continue
+		}
+		// A section emptied by the change gives way to the next one
+		if n := len(sections); n > 0 && sections[n-1].start == start {
+			sections[n-1] = section{name: s.name, start: start}
+			continue
+		}
+		sections = append(sections, section{name: s.name, start: start})
+	}
+	p.sections = sections
+
+	if len(p.loopAnchors) == 0 {
+		return
+	}
+	anchors := map[int]bool{}
+	for index := range p.loopAnchors {
+		if i := newIndex(index); i >= 0 {
+			anchors[i] = true
+		}
+	}
+	p.loopAnchors = anchors
+}
+
+// DedupePages removes pages whose title, description and fields
+// equal those of the page before it, and returns how many were
+// removed. Page footers set with SetPageFooters, SetPageFootersFormat
+// or SetPageFootersWithTimestamp are set again for the new positions
+// and sections and loop anchors move along with their pages.
+// Returns ErrAlreadyRunning while the paginator is running.
+func (p *Paginator) DedupePages() (int, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.running {
+		return 0, ErrAlreadyRunning
+	}
+	pages := p.Pages[:0:0]
+	var files []*discordgo.File
+	kept := make([]int, len(p.Pages))
+	for i, page := range p.Pages {
+		if i > 0 && samePageContent(p.Pages[i-1], page) {
+			kept[i] = -1
+			continue
+		}
+		kept[i] = len(pages)
+		pages = append(pages, page)
+		if i < len(p.PageFiles) {
+			files = append(files, p.PageFiles[i])
+		}
+	}
+	removed := len(p.Pages) - len(pages)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	count := len(p.Pages)
+	p.Pages = pages
+	if len(p.PageFiles) > 0 {
+		p.PageFiles = files
+	}
+	p.shiftPages(count, func(i int) int {
+		return kept[i]
+	})
+	p.applyFooters()
+	if p.Index.get() >= len(p.Pages) {
+		p.Index.Set(len(p.Pages) - 1)
+	}
+	return removed, nil
+}
+
+// samePageContent returns true if the pages have the same title, description and fields
+func samePageContent(a, b *discordgo.MessageEmbed) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Title != b.Title || a.Description != b.Description || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i, field := range a.Fields {
+		other := b.Fields[i]
+		if field == nil || other == nil {
+			if field != other {
+				return false
+			}
+			continue
+		}
+		if *field != *other {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a paginator on channelID with the same configuration,
+// callbacks and custom handlers but without pages, e.g. to create
+// paginators from a configured template.
+//    ses      : discordgo session
+//    channelID: channelID to spawn the paginator on
+func (p *Paginator) Clone(ses Sessioner, channelID string) *Paginator {
+	p.Lock()
+	defer p.Unlock()
+
+	c := NewPaginator(ses, channelID)
+	c.Loop = p.Loop
+	c.DeleteMessageWhenDone = p.DeleteMessageWhenDone
+	c.DeleteReactionsWhenDone = p.DeleteReactionsWhenDone
+	c.ColourWhenDone = p.ColourWhenDone
+	c.ColourAllPagesWhenDone = p.ColourAllPagesWhenDone
+	c.UseButtons = p.UseButtons
+	c.UseSelectMenu = p.UseSelectMenu
+	c.PerUserViews = p.PerUserViews
+	c.AllowedUsers = append([]string(nil), p.AllowedUsers...)
+	if p.ViewerMode != nil {
+		c.ViewerMode = &ViewerMode{Presenters: append([]string(nil), p.ViewerMode.Presenters...)}
+	}
+	c.RemoveUnauthorizedReactions = p.RemoveUnauthorizedReactions
+	c.StripForeignReactions = p.StripForeignReactions
+	c.QueryInputTimeout = p.QueryInputTimeout
+	c.DeleteQueryInput = p.DeleteQueryInput
+	c.EnableNumberJump = p.EnableNumberJump
+	c.NumberJumpPrompt = p.NumberJumpPrompt
+	c.ControlOrder = append([]string(nil), p.ControlOrder...)
+	c.InvalidPageNumberMessage = p.InvalidPageNumberMessage
+	c.JumpViaModal = p.JumpViaModal
+	c.EnableStopButton = p.EnableStopButton
+	if p.NavEmojis != nil {
+		nav := *p.NavEmojis
+		c.NavEmojis = &nav
+	}
+	c.EnableSearch = p.EnableSearch
+	c.HideUnavailableControls = p.HideUnavailableControls
+	c.Errors = p.Errors
+	c.Sticky = p.Sticky
+	c.StickyThreshold = p.StickyThreshold
+	c.StickyInterval = p.StickyInterval
+	c.Timeout = p.Timeout
+	c.RefreshAfterAction = p.RefreshAfterAction
+	c.IdleTimeout = p.IdleTimeout
+	c.MaxLifetime = p.MaxLifetime
+	c.AutoPageFooter = p.AutoPageFooter
+	c.ShowProgressBar = p.ShowProgressBar
+	c.ProgressBarWidth = p.ProgressBarWidth
+	if p.ProgressBarStyle != nil {
+		style := *p.ProgressBarStyle
+		c.ProgressBarStyle = &style
+	}
+	if p.AllowedMentions != nil {
+		mentions := *p.AllowedMentions
+		c.AllowedMentions = &mentions
+	}
+	c.ReplyTo = p.ReplyTo
+	c.MentionRepliedUser = p.MentionRepliedUser
+	c.ValidateOnSpawn = p.ValidateOnSpawn
+	c.EmojiGuildID = p.EmojiGuildID
+	c.MinUpdateInterval = p.MinUpdateInterval
+	c.RateLimitRetries = p.RateLimitRetries
+	c.ShowLoopHint = p.ShowLoopHint
+	c.ShowSectionInFooter = p.ShowSectionInFooter
+	c.TitleTemplate = p.TitleTemplate
+	c.StaticContent = p.StaticContent
+	c.LoadingContent = p.LoadingContent
+	c.RemoveOwnReactionsFallback = p.RemoveOwnReactionsFallback
+	c.ClampGoto = p.ClampGoto
+	c.EnableFastJump = p.EnableFastJump
+	c.JumpStep = p.JumpStep
+	c.Metrics = p.Metrics
+	c.StartIndex = p.StartIndex
+	c.PersistIndex = p.PersistIndex
+	c.OnPageChange = p.OnPageChange
+	c.OnStart = p.OnStart
+	c.OnRenderDone = p.OnRenderDone
+	c.OnStop = p.OnStop
+
+	w := p.Widget
+	c.Widget.Timeout = w.Timeout
+	c.Widget.IdleTimeout = w.IdleTimeout
+	c.Widget.MaxLifetime = w.MaxLifetime
+	c.Widget.DeleteReactions = w.DeleteReactions
+	c.Widget.ReactionAddDelay = w.ReactionAddDelay
+	c.Widget.RefreshAfterAction = w.RefreshAfterAction
+	c.Widget.UserWhitelist = append([]string(nil), w.UserWhitelist...)
+	c.Widget.RemoveUnauthorizedReactions = w.RemoveUnauthorizedReactions
+	c.Widget.StripForeignReactions = w.StripForeignReactions
+	c.Widget.AllowedMentions = w.AllowedMentions
+	// The clone has no PageBuilder, prepareMessage sets the flag again
+	c.Widget.Flags = w.Flags &^ discordgo.MessageFlagsIsComponentsV2
+	c.Widget.ReplyTo = w.ReplyTo
+	c.Widget.MentionRepliedUser = w.MentionRepliedUser
+	c.Widget.middleware = append([]Middleware(nil), w.middleware...)
+
+	// Copy custom handlers, the controls are added again on Spawn
+	keys := w.Keys
+	if p.handlersAdded {
+		keys = p.customKeys
+	}
+	for _, key := range keys {
+		if handler, ok := w.Handlers[key]; ok {
+			c.Widget.Handle(key, handler)
+		}
+	}
+	for customID, handler := range w.ComponentHandlers {
+		if !strings.HasPrefix(customID, ComponentPrefix) {
+			c.Widget.ComponentHandlers[customID] = handler
+		}
+	}
+	for key, handler := range w.removeHandlers {
+		c.Widget.HandleRemove(key, handler)
+	}
+	return c
+}
+
+// AddContent adds plain text pages to the paginator
+//    contents: text pages to add.
+func (p *Paginator) AddContent(contents ...string) {
+	p.Lock()
+	p.Contents = append(p.Contents, contents...)
+	p.Unlock()
+}
+
+// contentMode returns true if the paginator pages plain text
+// instead of embeds. A paginator is in content mode when
+// it has no embed pages but has content pages.
+func (p *Paginator) contentMode() bool {
+	return p.PageProvider == nil && len(p.Pages) == 0 && len(p.Contents) > 0
+}
+
+// componentsMode returns true if the pages are built as components v2
+func (p *Paginator) componentsMode() bool {
+	return p.PageBuilder != nil
+}
+
+// pageCount returns the amount of pages in the active page slice
+func (p *Paginator) pageCount() int {
+	if p.PageProvider != nil || p.PageBuilder != nil {
+		return p.TotalPages
+	}
+	if p.contentMode() {
+		return len(p.Contents)
+	}
+	return len(p.Pages)
+}
 
-	DeleteMessageWhenDone   bool
-	DeleteReactionsWhenDone bool
-	ColourWhenDone          int
+// PageContent returns the plain text page of the current index
+func (p *Paginator) PageContent() (string, error) {
+	return p.contentAt(p.CurrentIndex())
+}
 
-	running bool
+// contentAt returns the plain text page at index
+func (p *Paginator) contentAt(index int) (string, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if index < 0 || index >= len(p.Contents) {
+		return "", ErrIndexOutOfBounds
+	}
+	if p.footer != nil {
+		return p.Contents[index] + "\n\n" + p.footer(index, len(p.Contents), ""), nil
+	}
+	return p.Contents[index], nil
 }
 
+// Page returns the page of the current index
+func (p *Paginator) Page() (*discordgo.MessageEmbed, error) {
+	return p.pageAt(p.CurrentIndex())
+}
 
+// pageAt returns the page at index
+func (p *Paginator) pageAt(index int) (*discordgo.MessageEmbed, error) {
+	p.Lock()
+	defer p.Unlock()
+	return p.pageAtLocked(index)
+}
 
+// PageCopy returns a deep copy of the page of the current index
+// that can be read and modified without affecting the paginator
+func (p *Paginator) PageCopy() (*discordgo.MessageEmbed, error) {
+	p.Lock()
+	defer p.Unlock()
 
+	page, err := p.pageAtLocked(p.Index.get())
+	if err != nil {
+		return nil, err
+	}
+	return copyEmbed(page), nil
+}
 
-// NewPaginator returns a new Paginator
-//    ses      : discordgo session
-//    channelID: channelID to spawn the paginator on
-func NewPaginator(ses *discordgo.Session, channelID string) *Paginator {
-	p := &Paginator{
-		Ses:            ses,
-		Pages:          []*discordgo.MessageEmbed{},
-		ColourWhenDone: -1,
-		Widget:         NewWidget(ses, channelID, nil),
+// pageAtLocked returns the page at index while p is locked
+func (p *Paginator) pageAtLocked(index int) (*discordgo.MessageEmbed, error) {
+	if index < 0 || index >= p.pageCount() {
+		return nil, ErrIndexOutOfBounds
 	}
-	p.addHandlers()
 
-	return p
+	if p.PageProvider != nil {
+		return p.providePage(index), nil
+	}
+	// Content and components mode have no embed pages
+	if index >= len(p.Pages) {
+		return nil, ErrIndexOutOfBounds
+	}
+	return p.Pages[index], nil
 }
 
-func (p *Paginator) addHandlers() {
-	p.Widget.Handle(NavBeginning, func(w *Widget, r *discordgo.MessageReaction) {
-		if err := p.Goto(0); err == nil {
-			p.Update()
+// providePage returns the cached page at index or builds it with
+// p.PageProvider. Provider errors are returned as an error embed.
+func (p *Paginator) providePage(index int) *discordgo.MessageEmbed {
+	if page, ok := p.pageCache[index]; ok {
+		return page
+	}
+
+	page, err := p.PageProvider(index)
+	if err != nil {
+		return &discordgo.MessageEmbed{
+			Title:       "Failed to load page",
+			Description: err.Error(),
 		}
-	})
-	p.Widget.Handle(NavLeft, func(w *Widget, r *discordgo.MessageReaction) {
-		if err := p.PreviousPage(); err == nil {
-			p.Update()
+	}
+	if page == nil {
+		return &discordgo.MessageEmbed{
+			Title:       "Failed to load page",
+			Description: ErrNilEmbed.Error(),
 		}
-	})
-	p.Widget.Handle(NavRight, func(w *Widget, r *discordgo.MessageReaction) {
-		if err := p.NextPage(); err == nil {
-			p.Update()
+	}
+
+	if p.pageCache == nil {
+		p.pageCache = map[int]*discordgo.MessageEmbed{}
+	}
+	p.pageCache[index] = page
+	return page
+}
+
+// Jump moves the page index by step pages, stopping at the first or
+// last page or wrapping around when Loop is enabled.
+//    step: amount of pages to move by, negative to move back
+func (p *Paginator) Jump(step int) error {
+	return p.changePage(func() error {
+		total := p.pageCount()
+		if total == 0 {
+			return ErrIndexOutOfBounds
 		}
+		index := p.Index.get() + step
+		if p.Loop {
+			index = ((index % total) + total) % total
+		} else if index < 0 {
+			index = 0
+		} else if index >= total {
+			index = total - 1
+		}
+		p.Index.Set(index)
+		return nil
+	})
+}
+
+// numberJumpPrompt returns p.NumberJumpPrompt, or the default prompt when it isn't set
+func (p *Paginator) numberJumpPrompt() string {
+	if p.NumberJumpPrompt == "" {
+		return "Insert a page number to go to"
+	}
+	return p.NumberJumpPrompt
+}
+
+// invalidPageNumber sends InvalidPageNumberMessage and deletes it after a few seconds
+func (p *Paginator) invalidPageNumber(channelID string) {
+	if p.InvalidPageNumberMessage == "" {
+		return
+	}
+	unlock := lockChannel(channelID)
+	msg, err := p.Ses.ChannelMessageSend(channelID, p.InvalidPageNumberMessage)
+	unlock()
+	if err != nil {
+		p.reportError(wrapErr(err, "paginator: send invalid page number message to channel %s", channelID))
+		return
+	}
+	time.AfterFunc(5*time.Second, func() {
+		defer lockChannel(msg.ChannelID)()
+		p.Ses.ChannelMessageDelete(msg.ChannelID, msg.ID)
 	})
-	p.Widget.Handle(NavEnd, func(w *Widget, r *discordgo.MessageReaction) {
-		if err := p.Goto(len(p.Pages) - 1); err == nil {
-			p.Update()
+}
+
+// jumpStep returns p.JumpStep, or 10 when it isn't set
+func (p *Paginator) jumpStep() int {
+	if p.JumpStep <= 0 {
+		return 10
+	}
+	return p.JumpStep
+}
+
+// NextPage sets the page index to the next page
+func (p *Paginator) NextPage() error {
+	return p.changePage(func() error {
+		if next := p.Index.get() + 1; next >= 0 && next < p.pageCount() {
+			p.Index.Incr()
+			return nil
+		}
+
+		// Set the queue back to the beginning if Loop is enabled.
+		if p.Loop && p.canWrap(p.Index.get(), 0) {
+			p.Index.Set(0)
+			return nil
 		}
+
+		return ErrIndexOutOfBounds
 	})
-	p.Widget.Handle(NavNumbers, func(w *Widget, r *discordgo.MessageReaction) {
-		if msg, err := w.QueryInput("Insert a page number to go to", r.UserID, 10*time.Second); err == nil {
-			if n, err := strconv.Atoi(msg.Content); err == nil {
-				p.Goto(n - 1)
-				p.Update()
-			}
+}
+
+// PreviousPage sets the current page index to the previous page.
+func (p *Paginator) PreviousPage() error {
+	return p.changePage(func() error {
+		if previous := p.Index.get() - 1; previous >= 0 && previous < p.pageCount() {
+			p.Index.Decr()
+			return nil
+		}
+
+		// Set the queue back to the end if Loop is enabled.
+		if p.Loop && p.canWrap(p.Index.get(), p.pageCount()-1) {
+			p.Index.Set(p.pageCount() - 1)
+			return nil
 		}
+
+		return ErrIndexOutOfBounds
 	})
 }
 
-// Spawn spawns the paginator in channel p.ChannelID
-func (p *Paginator) Spawn() error {
-	if p.Running() {
-		return ErrAlreadyRunning
-	}
+// SetLoopAnchors marks pages that Loop doesn't wrap from or to, e.g.
+// so a last page with a destructive action isn't reached by going
+// back from the first page. Calling it without indices removes the anchors.
+//    indices: indexes of the anchor pages
+func (p *Paginator) SetLoopAnchors(indices ...int) {
 	p.Lock()
-	p.running = true
-	p.Unlock()
+	defer p.Unlock()
 
-	defer func() {
-		p.Lock()
-		p.running = false
-		p.Unlock()
-		// Delete Message when done
-		if p.DeleteMessageWhenDone && p.Widget.Message != nil {
-			p.Ses.ChannelMessageDelete(p.Widget.Message.ChannelID, p.Widget.Message.ID)
-		} else if p.ColourWhenDone >= 0 {
-			if page, err := p.Page(); err == nil {
-				page.Color = p.ColourWhenDone
-				p.Update()
-			}
+	p.loopAnchors = nil
+	for _, index := range indices {
+		if p.loopAnchors == nil {
+			p.loopAnchors = map[int]bool{}
 		}
+		p.loopAnchors[index] = true
+	}
+}
+
+// canWrap returns true if Loop may wrap from the page at from to the
+// page at to, which it can't when either is a loop anchor
+func (p *Paginator) canWrap(from, to int) bool {
+	return !p.loopAnchors[from] && !p.loopAnchors[to]
+}
 
-		// Delete reactions when done
-		if p.DeleteReactionsWhenDone && p.Widget.Message != nil {
-			p.Ses.MessageReactionsRemoveAll(p.Widget.ChannelID, p.Widget.Message.ID)
+// Goto jumps to the requested page index
+//    index: The index of the page to go to
+func (p *Paginator) Goto(index int) error {
+	return p.changePage(func() error {
+		if p.ClampGoto && p.pageCount() > 0 {
+			if index < 0 {
+				index = 0
+			} else if index >= p.pageCount() {
+				index = p.pageCount() - 1
+			}
 		}
-	}()
+		if index < 0 || index >= p.pageCount() {
+			return ErrIndexOutOfBounds
+		}
+		p.Index.Set(index)
+		return nil
+	})
+}
 
-	page, err := p.Page()
-	if err != nil {
-		return err
-	}
-	p.Widget.Embed = page
+// GotoFirst jumps to the first page.
+// Returns ErrIndexOutOfBounds when there are no pages.
+func (p *Paginator) GotoFirst() error {
+	return p.Goto(0)
+}
 
-	return p.Widget.Spawn()
+// GotoLast jumps to the last page.
+// Returns ErrIndexOutOfBounds when there are no pages.
+func (p *Paginator) GotoLast() error {
+	return p.changePage(func() error {
+		last := p.pageCount() - 1
+		if last < 0 {
+			return ErrIndexOutOfBounds
+		}
+		p.Index.Set(last)
+		return nil
+	})
 }
 
-// Add a page to the paginator
-//    embed: embed page to add.
-func (p *Paginator) Add(embeds ...*discordgo.MessageEmbed) {
-	p.Pages = append(p.Pages, embeds...)
+// reportError sends err to p.Errors without blocking
+func (p *Paginator) reportError(err error) {
+	if err == nil || p.Errors == nil {
+		return
+	}
+	select {
+	case p.Errors <- err:
+	default:
+	}
 }
 
-// Page returns the page of the current index
-func (p *Paginator) Page() (*discordgo.MessageEmbed, error) {
+// SearchPages returns the index of the first page whose title or
+// description contains query, ignoring case. Returns ErrIndexOutOfBounds
+// when no page matches.
+//    query: text to search for
+func (p *Paginator) SearchPages(query string) (int, error) {
 	p.Lock()
 	defer p.Unlock()
 
-	if p.Index.currentIndex < 0 || p.Index.currentIndex >= len(p.Pages) {
-		return nil, ErrIndexOutOfBounds
+	query = strings.ToLower(query)
+	if p.contentMode() {
+		for index, content := range p.Contents {
+			if strings.Contains(strings.ToLower(content), query) {
+				return index, nil
+			}
+		}
+		return -1, ErrIndexOutOfBounds
 	}
 
-	return p.Pages[p.Index.currentIndex], nil
+	for index, embed := range p.Pages {
+		if strings.Contains(strings.ToLower(embed.Title), query) ||
+			strings.Contains(strings.ToLower(embed.Description), query) {
+			return index, nil
+		}
+	}
+	return -1, ErrIndexOutOfBounds
 }
 
-// NextPage sets the page index to the next page
-func (p *Paginator) NextPage() error {
+// changePage runs fn while holding the paginator lock and calls
+// OnPageChange outside of the lock if the current index changed.
+func (p *Paginator) changePage(fn func() error) error {
 	p.Lock()
-	defer p.Unlock()
+	oldIndex := p.Index.get()
+	err := fn()
+	newIndex := p.Index.get()
+	p.Unlock()
 
-	if p.Index.currentIndex+1 >= 0 && p.Index.currentIndex+1 < len(p.Pages) {
-		p.Index.Incr()
-		return nil
+	if err == nil && oldIndex != newIndex {
+		if p.OnPageChange != nil {
+			p.OnPageChange(p, oldIndex, newIndex)
+		}
+		if p.PersistIndex != nil {
+			p.PersistIndex(newIndex)
+		}
 	}
+	return err
+}
 
-	// Set the queue back to the beginning if Loop is enabled.
-	if p.Loop {
-		p.Index.Set(0)
+// Update updates the message with the current state of the paginator.
+// When MinUpdateInterval is set, the edit is delayed until Update
+// hasn't been called for MinUpdateInterval and errors are sent to p.Errors.
+func (p *Paginator) Update() error {
+	if p.Widget.message() == nil {
+		return ErrNilMessage
+	}
+	if p.Widget.RefreshAfterAction && p.Widget.ticker != nil {
+		_ = p.Widget.RefreshTimeout() // ignore error because ticker will always be present
+	}
+
+	if p.MinUpdateInterval > 0 {
+		p.Lock()
+		if p.updateTimer == nil {
+			p.updateTimer = time.AfterFunc(p.MinUpdateInterval, func() {
+				p.reportError(p.update())
+			})
+		} else {
+			p.updateTimer.Reset(p.MinUpdateInterval)
+		}
+		p.Unlock()
 		return nil
 	}
 
-	return ErrIndexOutOfBounds
+	return p.update()
 }
 
-// PreviousPage sets the current page index to the previous page.
-func (p *Paginator) PreviousPage() error {
-	p.Lock()
-	defer p.Unlock()
+// update edits the message with the current page, retrying
+// after rate limits so the message ends up matching the index
+func (p *Paginator) update() error {
+	retries := p.RateLimitRetries
+	if retries == 0 {
+		retries = 1
+	}
+	for {
+		err := p.updateOnce()
+		retryAfter, limited := rateLimitRetryAfter(err)
+		if !limited || retries <= 0 {
+			return err
+		}
+		retries--
+		time.Sleep(retryAfter)
+	}
+}
 
-	if p.Index.currentIndex-1 >= 0 && p.Index.currentIndex-1 < len(p.Pages) {
-		p.Index.Decr()
-		return nil
+// updateOnce edits the message to show the current page
+func (p *Paginator) updateOnce() error {
+	if p.Widget.message() == nil {
+		return ErrNilMessage
+	}
+	index := p.CurrentIndex()
+	if err := p.refreshControls(); err != nil {
+		return wrapErr(err, "paginator: refresh controls on page %d", index)
+	}
+	if p.componentsMode() {
+		return wrapErr(p.Widget.UpdateComponents(p.messageComponents(index)), "paginator: update to page %d", index)
 	}
 
-	// Set the queue back to the beginning if Loop is enabled.
-	if p.Loop {
-		p.Index.currentIndex = len(p.Pages) - 1
-		return nil
+	// The buttons and the select menu's options follow the current
+	// page, they are edited together with it
+	var update messageUpdate
+	if (p.UseButtons && p.HideUnavailableControls) || p.UseSelectMenu {
+		components := p.navComponents(index)
+		update.components = &components
+	}
+
+	if p.contentMode() {
+		content, err := p.PageContent()
+		if err != nil {
+			return wrapErr(err, "paginator: render page %d", index)
+		}
+		if update.components == nil {
+			_, err = p.Widget.UpdateContent(content)
+			return wrapErr(err, "paginator: update to page %d", index)
+		}
+		update.content = &content
+		_, err = p.Widget.update(update)
+		return wrapErr(err, "paginator: update to page %d", index)
+	}
+
+	page, err := p.renderPage()
+	if err != nil {
+		return wrapErr(err, "paginator: render page %d", index)
+	}
+
+	if update.components != nil {
+		update.embeds = &[]*discordgo.MessageEmbed{page}
+		if len(p.PageFiles) > 0 {
+			update.files = p.pageFiles(index)
+			update.replaceFiles = true
+		} else if p.StaticContent != "" {
+			update.content = &p.StaticContent
+		}
+		_, err = p.Widget.update(update)
+	} else if len(p.PageFiles) > 0 {
+		_, err = p.Widget.UpdateEmbedWithFiles(page, p.pageFiles(index))
+	} else if p.StaticContent != "" {
+		_, err = p.Widget.UpdateMessage(p.StaticContent, page)
+	} else {
+		_, err = p.Widget.UpdateEmbed(page)
 	}
+	return wrapErr(err, "paginator: update to page %d", index)
+}
 
-	return ErrIndexOutOfBounds
+// progressBar returns the progress bar of the page at index
+func (p *Paginator) progressBar(index, total int) string {
+	width := p.ProgressBarWidth
+	if width <= 0 {
+		width = 10
+	}
+	style := DefaultProgressBarStyle()
+	if p.ProgressBarStyle != nil {
+		style = *p.ProgressBarStyle
+	}
+	return progressBar(index, total, width, style)
 }
 
-// Goto jumps to the requested page index
-//    index: The index of the page to go to
-func (p *Paginator) Goto(index int) error {
+// pageFiles returns the files of the page at index, rewinding
+// their readers so they can be sent again
+func (p *Paginator) pageFiles(index int) []*discordgo.File {
 	p.Lock()
 	defer p.Unlock()
-	if index < 0 || index >= len(p.Pages) {
-		return ErrIndexOutOfBounds
+
+	if index < 0 || index >= len(p.PageFiles) || p.PageFiles[index] == nil {
+		return nil
 	}
-	p.Index.Set(index)
-	return nil
+	file := p.PageFiles[index]
+	if seeker, ok := file.Reader.(io.Seeker); ok {
+		seeker.Seek(0, io.SeekStart)
+	}
+	return []*discordgo.File{file}
 }
 
-// Update updates the message with the current state of the paginator
-func (p *Paginator) Update() error {
-	if p.Widget.Message == nil {
-		return ErrNilMessage
+// renderPage returns the current page as it should be displayed
+func (p *Paginator) renderPage() (*discordgo.MessageEmbed, error) {
+	return p.renderPageAt(p.CurrentIndex())
+}
+
+// renderPageAt returns the page at index as it should be displayed
+func (p *Paginator) renderPageAt(index int) (*discordgo.MessageEmbed, error) {
+	page, err := p.pageAt(index)
+	if err != nil || !(p.AutoPageFooter || p.ShowProgressBar || p.TitleTemplate != "" || p.ShowSectionInFooter) {
+		return page, err
 	}
-	if p.Widget.RefreshAfterAction && p.Widget.ticker != nil {
-		_ = p.Widget.RefreshTimeout() // ignore error because ticker will always be present
+	total := p.PageCount()
+
+	// Copy the page so the changes don't leak into Pages
+	rendered := *page
+	if p.TitleTemplate != "" {
+		rendered.Title = strings.NewReplacer(
+			"{page}", strconv.Itoa(index+1),
+			"{total}", strconv.Itoa(total),
+			"{title}", page.Title,
+		).Replace(p.TitleTemplate)
 	}
-	page, err := p.Page()
-	if err != nil {
-		return err
+	text := p.pageIndicator(index, total)
+	if p.ShowSectionInFooter {
+		if name := p.sectionName(index); name != "" {
+			if text == "" && page.Footer != nil {
+				text = page.Footer.Text
+			}
+			if text != "" {
+				text = name + " • " + text
+			} else {
+				text = name
+			}
+		}
 	}
+	if text != "" {
+		footer := discordgo.MessageEmbedFooter{}
+		if page.Footer != nil {
+			footer = *page.Footer
+		}
+		footer.Text = text
+		rendered.Footer = &footer
+	}
+	return &rendered, nil
+}
 
-	_, err = p.Widget.UpdateEmbed(page)
-	return err
+// pageIndicator returns the footer text of AutoPageFooter or
+// ShowProgressBar for the page at index, or "" if none is shown
+func (p *Paginator) pageIndicator(index, total int) string {
+	var text string
+	if p.ShowProgressBar {
+		if total <= 1 {
+			return ""
+		}
+		text = p.progressBar(index, total)
+	} else if p.AutoPageFooter {
+		text = fmt.Sprintf("Page %d/%d", index+1, total)
+	} else {
+		return ""
+	}
+	if p.Loop && p.ShowLoopHint && total > 1 && p.wrapsAt(index, total) {
+		text += " (loops)"
+	}
+	return text
+}
+
+// wrapsAt returns true if Loop wraps around from the page at index,
+// i.e. it is the first or last page and neither end is a loop anchor
+func (p *Paginator) wrapsAt(index, total int) bool {
+	p.Lock()
+	defer p.Unlock()
+	return (index == 0 && p.canWrap(0, total-1)) || (index == total-1 && p.canWrap(total-1, 0))
+}
+
+// stopUpdateTimer stops a delayed update.
+// Returns true if an update was pending.
+func (p *Paginator) stopUpdateTimer() bool {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.updateTimer == nil {
+		return false
+	}
+	pending := p.updateTimer.Stop()
+	p.updateTimer = nil
+	return pending
 }
 
 // Running returns the running status of the paginator
@@ -210,14 +1981,132 @@ func (p *Paginator) Running() bool {
 	return running
 }
 
+// CurrentIndex returns the index of the current page
+func (p *Paginator) CurrentIndex() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.Index.get()
+}
+
+// PageCount returns the amount of pages
+func (p *Paginator) PageCount() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.pageCount()
+}
+
+// Message returns the paginator's message, or nil if it hasn't been sent yet
+func (p *Paginator) Message() *discordgo.Message {
+	return p.Widget.message()
+}
+
 // SetPageFooters sets the footer of each embed to
 // Be its page number out of the total length of the embeds.
+// In content mode the page number is shown below each page's text.
+// It replaces the footers of earlier SetPageFooters calls.
 func (p *Paginator) SetPageFooters() {
+	p.Lock()
+	defer p.Unlock()
+	p.setFooters(func(index, total int, _ string) string {
+		return fmt.Sprintf("Page #%d out of %d", index+1, total)
+	})
+}
+
+// setFooters sets footer as the page footer while p is locked
+func (p *Paginator) setFooters(footer func(index, total int, existing string) string) {
+	p.footer = footer
+	if !p.contentMode() {
+		p.applyFooters()
+	}
+}
+
+// applyFooters sets the footer text of each embed page to p.footer
+// for its current position while p is locked. The text the page had
+// before footers were set is passed as the existing text.
+func (p *Paginator) applyFooters() {
+	if p.footer == nil {
+		return
+	}
+	if p.footerBase == nil {
+		p.footerBase = map[*discordgo.MessageEmbed]string{}
+	}
+	for index, embed := range p.Pages {
+		existing, ok := p.footerBase[embed]
+		if !ok {
+			if embed.Footer != nil {
+				existing = embed.Footer.Text
+			}
+			p.footerBase[embed] = existing
+		}
+		footer := discordgo.MessageEmbedFooter{}
+		if embed.Footer != nil {
+			footer = *embed.Footer
+		}
+		footer.Text = p.footer(index, len(p.Pages), existing)
+		embed.Footer = &footer
+	}
+}
+
+// ApplyColorPalette colours the pages with the colours of the palette
+// in turn, starting over after the last colour. ColourWhenDone still
+// replaces them once the paginator stops.
+//    colors   : colours of the palette
+//    overwrite: replace colours that are already set
+func (p *Paginator) ApplyColorPalette(colors []int, overwrite bool) {
+	if len(colors) == 0 {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
 	for index, embed := range p.Pages {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Page #%d out of %d", index+1, len(p.Pages)),
+		if embed.Color == 0 || overwrite {
+			embed.Color = colors[index%len(colors)]
+		}
+	}
+}
+
+// SetPageFootersWithTimestamp sets the footer of each embed to
+// "Page x/y • label" and its timestamp to the current time, e.g. to
+// show when the paginated data was generated.
+// In content mode the footer text is shown below each page's text.
+// It replaces the footers of earlier SetPageFooters calls.
+//    label    : text shown after the page number
+//    overwrite: replace timestamps that are already set
+func (p *Paginator) SetPageFootersWithTimestamp(label string, overwrite bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	if !p.contentMode() {
+		now := time.Now().Format(time.RFC3339)
+		for _, embed := range p.Pages {
+			if embed.Timestamp == "" || overwrite {
+				embed.Timestamp = now
+			}
 		}
 	}
+	p.setFooters(func(index, total int, _ string) string {
+		return fmt.Sprintf("Page %d/%d • %s", index+1, total, label)
+	})
+}
+
+// SetPageFootersFormat sets the footer of each embed from format,
+// keeping existing footer text when format includes {existing}.
+// In content mode the formatted text is shown below each page's text.
+// It replaces the footers of earlier SetPageFooters calls, {existing}
+// is the footer text the page had before them.
+//    format: footer text, the placeholders {current}, {total} and
+//            {existing} are replaced with the page number, the amount
+//            of pages and the existing footer text
+func (p *Paginator) SetPageFootersFormat(format string) {
+	p.Lock()
+	defer p.Unlock()
+	p.setFooters(func(index, total int, existing string) string {
+		return strings.TrimSpace(strings.NewReplacer(
+			"{current}", strconv.Itoa(index+1),
+			"{total}", strconv.Itoa(total),
+			"{existing}", existing,
+		).Replace(format))
+	})
 }
 
 // Sub is a subscriber interface
@@ -225,38 +2114,62 @@ type Sub interface {
 	OnNotify(index int)
 }
 
-// Index implements observer pattern for handling events on Paginator index change
+// Index implements observer pattern for handling events on Paginator index change.
+// It is safe for concurrent use.
 type Index struct {
+	sync.RWMutex
 	subs         []Sub
 	currentIndex int
 }
 
 // AddSub adds subscribers to the list
 func (i *Index) AddSub(s ...Sub) {
+	i.Lock()
 	i.subs = append(i.subs, s...)
+	i.Unlock()
 }
 
 // Set sets index at given number in and notifies all subscribers
 func (i *Index) Set(in int) {
+	i.Lock()
 	i.currentIndex = in
+	i.Unlock()
 	i.notify()
 }
 
 // Incr increments index and notifies all subscribers
 func (i *Index) Incr() {
+	i.Lock()
 	i.currentIndex++
+	i.Unlock()
 	i.notify()
 }
 
 // Decr decrements index and notifies all subscribers
 func (i *Index) Decr() {
+	i.Lock()
 	i.currentIndex--
+	i.Unlock()
 	i.notify()
 }
 
-// notify notifies all subs
-func (i Index) notify() {
-	for _, s := range i.subs {
-		s.OnNotify(i.currentIndex)
+// get returns the current index
+func (i *Index) get() int {
+	i.RLock()
+	defer i.RUnlock()
+	return i.currentIndex
+}
+
+// notify notifies all subs.
+// Subscribers are called without holding the lock.
+func (i *Index) notify() {
+	i.RLock()
+	subs := make([]Sub, len(i.subs))
+	copy(subs, i.subs)
+	currentIndex := i.currentIndex
+	i.RUnlock()
+
+	for _, s := range subs {
+		s.OnNotify(currentIndex)
 	}
 }
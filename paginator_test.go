@@ -0,0 +1,594 @@
+package dgwidgets_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	dgwidgets "github.com/deveopinghitloa/widgets"
+	"github.com/deveopinghitloa/widgets/dgtest"
+)
+
+// waitFor fails the test if cond doesn't become true within a second
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// newPaginator returns a paginator on a fake session with pages titled "1" to "n"
+func newPaginator(n int) (*dgwidgets.Paginator, *dgtest.FakeSession) {
+	ses := dgtest.NewFakeSession("bot")
+	p := dgwidgets.NewPaginator(ses, "channel")
+	for i := 1; i <= n; i++ {
+		p.Add(&discordgo.MessageEmbed{Title: strconv.Itoa(i)})
+	}
+	p.Widget.ReactionAddDelay = 0
+	return p, ses
+}
+
+// spawn spawns p in the background and returns its message,
+// stopping it when the test ends
+func spawn(t *testing.T, p *dgwidgets.Paginator) *discordgo.Message {
+	t.Helper()
+	if err := p.SpawnAsync(); err != nil {
+		t.Fatalf("SpawnAsync: %v", err)
+	}
+	t.Cleanup(func() {
+		p.Stop()
+		p.Wait()
+	})
+	waitFor(t, "the message", func() bool { return p.Message() != nil })
+	return p.Message()
+}
+
+// TestPaginatorSpawnStop stops paginators right after spawning them,
+// before their event loop is listening, and expects all of them to stop
+func TestPaginatorSpawnStop(t *testing.T) {
+	deadline := time.After(10 * time.Second)
+	for i := 0; i < 200; i++ {
+		p := dgwidgets.NewPaginator(dgtest.NewFakeSession("bot"), "channel")
+		p.Add(&discordgo.MessageEmbed{Title: "1"}, &discordgo.MessageEmbed{Title: "2"})
+		p.Widget.ReactionAddDelay = 0
+
+		if err := p.SpawnAsync(); err != nil {
+			t.Fatalf("iteration %d: SpawnAsync: %v", i, err)
+		}
+		if err := p.Stop(); err != nil {
+			t.Fatalf("iteration %d: Stop: %v", i, err)
+		}
+
+		stopped := make(chan error, 1)
+		go func() {
+			reason, err := p.Wait()
+			if err == nil && reason != dgwidgets.StopUser {
+				t.Errorf("iteration %d: stopped with reason %v, want StopUser", i, reason)
+			}
+			stopped <- err
+		}()
+		select {
+		case err := <-stopped:
+			if err != nil {
+				t.Fatalf("iteration %d: Wait: %v", i, err)
+			}
+		case <-deadline:
+			t.Fatalf("iteration %d: paginator didn't stop", i)
+		}
+		if p.Running() {
+			t.Fatalf("iteration %d: paginator still running after Wait", i)
+		}
+	}
+}
+
+func TestCloneOfComponentsPaginator(t *testing.T) {
+	p, ses := newPaginator(0)
+	p.PageBuilder = func(index int) []discordgo.MessageComponent {
+		return []discordgo.MessageComponent{discordgo.TextDisplay{Content: strconv.Itoa(index + 1)}}
+	}
+	p.TotalPages = 2
+	spawn(t, p)
+	if flags := p.Widget.Flags; flags&discordgo.MessageFlagsIsComponentsV2 == 0 {
+		t.Fatalf("flags = %d, want components v2", flags)
+	}
+
+	c := p.Clone(ses, "other")
+	c.Add(&discordgo.MessageEmbed{Title: "embed"})
+	messages, err := c.Render()
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if flags := messages[0].Flags; flags&discordgo.MessageFlagsIsComponentsV2 != 0 {
+		t.Fatalf("clone with embed pages renders flags %d, want no components v2", flags)
+	}
+}
+
+func TestInsertPageMovesIndex(t *testing.T) {
+	p, _ := newPaginator(3)
+	if err := p.Goto(2); err != nil {
+		t.Fatalf("Goto: %v", err)
+	}
+	var changes [][2]int
+	var persisted []int
+	p.OnPageChange = func(_ *dgwidgets.Paginator, oldIndex, newIndex int) {
+		changes = append(changes, [2]int{oldIndex, newIndex})
+	}
+	p.PersistIndex = func(index int) {
+		persisted = append(persisted, index)
+	}
+
+	// Inserting after the current page keeps the index
+	if err := p.InsertPage(3, &discordgo.MessageEmbed{Title: "end"}); err != nil {
+		t.Fatalf("InsertPage: %v", err)
+	}
+	if got := p.CurrentIndex(); got != 2 {
+		t.Fatalf("CurrentIndex = %d, want 2", got)
+	}
+
+	if err := p.InsertPage(0, &discordgo.MessageEmbed{Title: "summary"}); err != nil {
+		t.Fatalf("InsertPage: %v", err)
+	}
+	if got := p.CurrentIndex(); got != 3 {
+		t.Fatalf("CurrentIndex = %d, want 3", got)
+	}
+	if page, _ := p.Page(); page.Title != "3" {
+		t.Fatalf("current page = %q, want %q", page.Title, "3")
+	}
+	if want := [][2]int{{2, 3}}; !reflect.DeepEqual(changes, want) {
+		t.Fatalf("OnPageChange calls = %v, want %v", changes, want)
+	}
+	if want := []int{3}; !reflect.DeepEqual(persisted, want) {
+		t.Fatalf("PersistIndex calls = %v, want %v", persisted, want)
+	}
+
+	if err := p.InsertPage(7); err != dgwidgets.ErrIndexOutOfBounds {
+		t.Fatalf("InsertPage out of bounds = %v, want ErrIndexOutOfBounds", err)
+	}
+}
+
+func TestMiddlewareSeesEveryReaction(t *testing.T) {
+	p, ses := newPaginator(3)
+	seen := make(chan string, 10)
+	p.Widget.Use(func(_ *dgwidgets.Widget, r *discordgo.MessageReaction) bool {
+		seen <- r.Emoji.Name
+		return r.Emoji.Name != dgwidgets.NavRight
+	})
+	msg := spawn(t, p)
+
+	ses.React(msg.ChannelID, msg.ID, "user", "👍")
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NavRight)
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NavEnd)
+	waitFor(t, "the last page", func() bool {
+		edited, _ := ses.Message(msg.ID)
+		return edited.Embeds[0].Title == "3"
+	})
+
+	close(seen)
+	var got []string
+	for name := range seen {
+		got = append(got, name)
+	}
+	if want := []string{"👍", dgwidgets.NavRight, dgwidgets.NavEnd}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("middleware saw %q, want %q", got, want)
+	}
+	if calls := ses.CallsTo("ChannelMessageEditEmbed"); len(calls) != 1 {
+		t.Fatalf("message edited %d times, want 1 for the rejected reaction", len(calls))
+	}
+}
+
+func TestHiddenControlsKeepTheirPlace(t *testing.T) {
+	p, ses := newPaginator(3)
+	p.HideUnavailableControls = true
+	msg := spawn(t, p)
+
+	for _, step := range []struct {
+		emoji string
+		want  []string
+	}{
+		{"", []string{dgwidgets.NavRight, dgwidgets.NavEnd, dgwidgets.NavNumbers}},
+		{dgwidgets.NavRight, []string{dgwidgets.NavBeginning, dgwidgets.NavLeft, dgwidgets.NavRight, dgwidgets.NavEnd, dgwidgets.NavNumbers}},
+		{dgwidgets.NavEnd, []string{dgwidgets.NavBeginning, dgwidgets.NavLeft, dgwidgets.NavNumbers}},
+		{dgwidgets.NavBeginning, []string{dgwidgets.NavRight, dgwidgets.NavEnd, dgwidgets.NavNumbers}},
+	} {
+		if step.emoji != "" {
+			ses.React(msg.ChannelID, msg.ID, "user", step.emoji)
+		}
+		waitFor(t, "the reactions after "+step.emoji, func() bool {
+			return reflect.DeepEqual(ses.MessageReactions(msg.ID), step.want)
+		})
+	}
+}
+
+func TestPageTurnIsOneEdit(t *testing.T) {
+	p, ses := newPaginator(3)
+	p.UseButtons = true
+	p.HideUnavailableControls = true
+	p.UseSelectMenu = true
+	msg := spawn(t, p)
+
+	ses.ClickButton(msg.ChannelID, msg.ID, "user", dgwidgets.ComponentNext)
+	waitFor(t, "page 2", func() bool {
+		edited, _ := ses.Message(msg.ID)
+		return edited.Embeds[0].Title == "2"
+	})
+	ses.ClickButton(msg.ChannelID, msg.ID, "user", dgwidgets.ComponentNext)
+	waitFor(t, "page 3", func() bool {
+		edited, _ := ses.Message(msg.ID)
+		return edited.Embeds[0].Title == "3"
+	})
+
+	edits := ses.CallsTo("ChannelMessageEditComplex")
+	if n := len(edits) + len(ses.CallsTo("ChannelMessageEditEmbed")); n != 2 {
+		t.Fatalf("message edited %d times, want once per page turn", n)
+	}
+	for i, call := range edits {
+		edit := call.Args[0].(*discordgo.MessageEdit)
+		if edit.Embeds == nil || edit.Components == nil {
+			t.Fatalf("edit %d doesn't set both the page and the components", i)
+		}
+	}
+}
+
+func TestLoopHintFollowsAnchors(t *testing.T) {
+	for _, tt := range []struct {
+		anchors []int
+		want    []string
+	}{
+		{nil, []string{"Page 1/3 (loops)", "Page 2/3", "Page 3/3 (loops)"}},
+		{[]int{1}, []string{"Page 1/3 (loops)", "Page 2/3", "Page 3/3 (loops)"}},
+		{[]int{2}, []string{"Page 1/3", "Page 2/3", "Page 3/3"}},
+	} {
+		p, _ := newPaginator(3)
+		p.Loop = true
+		p.AutoPageFooter = true
+		p.ShowLoopHint = true
+		p.SetLoopAnchors(tt.anchors...)
+
+		msgs, err := p.Render()
+		if err != nil {
+			t.Fatalf("Render: %v", err)
+		}
+		var got []string
+		for _, msg := range msgs {
+			got = append(got, msg.Embeds[0].Footer.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("anchors %v: footers = %q, want %q", tt.anchors, got, tt.want)
+		}
+	}
+}
+
+// respondedWith returns the types of the responses to interactions
+func respondedWith(ses *dgtest.FakeSession) []discordgo.InteractionResponseType {
+	var types []discordgo.InteractionResponseType
+	for _, call := range ses.CallsTo("InteractionRespond") {
+		types = append(types, call.Args[1].(*discordgo.InteractionResponse).Type)
+	}
+	return types
+}
+
+func TestViewInteractions(t *testing.T) {
+	p, ses := newPaginator(3)
+	p.UseButtons = true
+	p.PerUserViews = true
+	p.AllowedUsers = []string{"user"}
+	p.IdleTimeout = 150 * time.Millisecond
+	msg := spawn(t, p)
+
+	// Users that aren't allowed are answered without opening a view
+	ses.ClickButton(msg.ChannelID, msg.ID, "stranger", dgwidgets.ComponentNext)
+	want := []discordgo.InteractionResponseType{discordgo.InteractionResponseDeferredMessageUpdate}
+	if got := respondedWith(ses); !reflect.DeepEqual(got, want) {
+		t.Fatalf("responses = %v, want %v", got, want)
+	}
+
+	// Using the views keeps the paginator from going idle
+	for i := 0; i < 6; i++ {
+		ses.ClickButton(msg.ChannelID, msg.ID, "user", dgwidgets.ComponentNext)
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !p.Widget.Running() {
+		t.Fatal("paginator timed out while its views were used")
+	}
+	if got := len(respondedWith(ses)); got != 7 {
+		t.Fatalf("%d interactions answered, want 7", got)
+	}
+	if reason, err := p.Wait(); err != nil || reason != dgwidgets.StopTimeout {
+		t.Fatalf("Wait = %v, %v, want StopTimeout", reason, err)
+	}
+}
+
+// submitJumpModal submits the jump modal of the paginator's message
+func submitJumpModal(ses *dgtest.FakeSession, msg *discordgo.Message, userID, page string) {
+	ses.Emit(&discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        "modal-" + userID + "-" + page,
+			Type:      discordgo.InteractionModalSubmit,
+			ChannelID: msg.ChannelID,
+			Message:   msg,
+			User:      &discordgo.User{ID: userID},
+			Data: discordgo.ModalSubmitInteractionData{
+				CustomID: dgwidgets.ComponentJumpModal + ":" + msg.ID,
+				Components: []discordgo.MessageComponent{
+					&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+						&discordgo.TextInput{CustomID: dgwidgets.ComponentJumpInput, Value: page},
+					}},
+				},
+			},
+		},
+	})
+}
+
+func TestJumpModalInteractions(t *testing.T) {
+	p, ses := newPaginator(3)
+	p.UseButtons = true
+	p.JumpViaModal = true
+	p.AllowedUsers = []string{"user"}
+	p.IdleTimeout = 150 * time.Millisecond
+	msg := spawn(t, p)
+
+	// Users that aren't allowed are answered without the modal or a jump
+	ses.ClickButton(msg.ChannelID, msg.ID, "stranger", dgwidgets.ComponentNumbers)
+	submitJumpModal(ses, msg, "stranger", "3")
+	deferred := discordgo.InteractionResponseDeferredMessageUpdate
+	want := []discordgo.InteractionResponseType{deferred, deferred}
+	if got := respondedWith(ses); !reflect.DeepEqual(got, want) {
+		t.Fatalf("responses = %v, want %v", got, want)
+	}
+	if got := p.CurrentIndex(); got != 0 {
+		t.Fatalf("CurrentIndex = %d, want 0", got)
+	}
+
+	// Using the modal keeps the paginator from going idle
+	for i := 0; i < 3; i++ {
+		ses.ClickButton(msg.ChannelID, msg.ID, "user", dgwidgets.ComponentNumbers)
+		time.Sleep(50 * time.Millisecond)
+		submitJumpModal(ses, msg, "user", strconv.Itoa(i+1))
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !p.Widget.Running() {
+		t.Fatal("paginator timed out while its jump modal was used")
+	}
+	want = append(want, discordgo.InteractionResponseModal, deferred, discordgo.InteractionResponseModal, deferred, discordgo.InteractionResponseModal, deferred)
+	if got := respondedWith(ses); !reflect.DeepEqual(got, want) {
+		t.Fatalf("responses = %v, want %v", got, want)
+	}
+	waitFor(t, "page 3", func() bool { return p.CurrentIndex() == 2 })
+}
+
+func TestMenuControls(t *testing.T) {
+	ses := dgtest.NewFakeSession("bot")
+	options := make([]string, 12)
+	for i := range options {
+		options[i] = "option " + strconv.Itoa(i+1)
+	}
+	m := dgwidgets.NewMenu(ses, "channel", "Pick one", options)
+	m.Widget.ReactionAddDelay = 0
+
+	picked := make(chan int, 1)
+	go func() {
+		index, err := m.Select(context.Background())
+		if err != nil {
+			t.Errorf("Select: %v", err)
+		}
+		picked <- index
+	}()
+	waitFor(t, "the message", func() bool { return m.Message() != nil })
+	msg := m.Message()
+
+	want := append([]string{dgwidgets.NavBeginning, dgwidgets.NavLeft, dgwidgets.NavRight, dgwidgets.NavEnd}, dgwidgets.NumberEmojis...)
+	waitFor(t, "the reactions", func() bool { return len(ses.MessageReactions(msg.ID)) == len(want) })
+	if got := ses.MessageReactions(msg.ID); !reflect.DeepEqual(got, want) {
+		t.Fatalf("reactions = %q, want %q", got, want)
+	}
+
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NavRight)
+	waitFor(t, "the second page", func() bool { return m.CurrentIndex() == 1 })
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NumberEmojis[1])
+	if index := <-picked; index != 11 {
+		t.Fatalf("picked %d, want 11", index)
+	}
+}
+
+func TestViewerModeNeedsPresenters(t *testing.T) {
+	p, ses := newPaginator(3)
+	p.ViewerMode = &dgwidgets.ViewerMode{}
+	if err := p.SpawnAsync(); err != dgwidgets.ErrNoPresenters {
+		t.Fatalf("SpawnAsync = %v, want ErrNoPresenters", err)
+	}
+	if calls := ses.CallsTo("ChannelMessageSendComplex"); len(calls) != 0 {
+		t.Fatalf("sent %d messages, want none", len(calls))
+	}
+
+	p.AllowedUsers = []string{"user"}
+	msg := spawn(t, p)
+	// Only the allowed user presents
+	ses.React(msg.ChannelID, msg.ID, "stranger", dgwidgets.NavEnd)
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NavRight)
+	waitFor(t, "page 2", func() bool {
+		edited, _ := ses.Message(msg.ID)
+		return edited.Embeds[0].Title == "2"
+	})
+	if got := p.CurrentIndex(); got != 1 {
+		t.Fatalf("CurrentIndex = %d, want 1", got)
+	}
+}
+
+func TestRenderMatchesSentReply(t *testing.T) {
+	p, ses := newPaginator(2)
+	p.ReplyTo = &discordgo.MessageReference{MessageID: "command", ChannelID: "channel"}
+
+	rendered, err := p.Render()
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	spawn(t, p)
+	sends := ses.CallsTo("ChannelMessageSendComplex")
+	if len(sends) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sends))
+	}
+	sent := sends[0].Args[1].(*discordgo.MessageSend)
+
+	if !reflect.DeepEqual(rendered[0].Reference, sent.Reference) {
+		t.Fatalf("rendered reference = %+v, sent %+v", rendered[0].Reference, sent.Reference)
+	}
+	if !reflect.DeepEqual(rendered[0].AllowedMentions, sent.AllowedMentions) {
+		t.Fatalf("rendered allowed mentions = %+v, sent %+v", rendered[0].AllowedMentions, sent.AllowedMentions)
+	}
+	if sent.AllowedMentions == nil || sent.AllowedMentions.RepliedUser {
+		t.Fatal("reply pings the replied user without MentionRepliedUser")
+	}
+}
+
+func TestRemovePageMovesIndex(t *testing.T) {
+	p, _ := newPaginator(4)
+	if err := p.Goto(2); err != nil {
+		t.Fatalf("Goto: %v", err)
+	}
+	var changes [][2]int
+	p.OnPageChange = func(_ *dgwidgets.Paginator, oldIndex, newIndex int) {
+		changes = append(changes, [2]int{oldIndex, newIndex})
+	}
+
+	for _, step := range []struct {
+		remove int
+		title  string
+	}{
+		{0, "3"}, // before the current page
+		{2, "3"}, // after the current page
+		{1, "2"}, // the current and last page
+	} {
+		if err := p.RemovePage(step.remove); err != nil {
+			t.Fatalf("RemovePage(%d): %v", step.remove, err)
+		}
+		if page, err := p.Page(); err != nil || page.Title != step.title {
+			t.Fatalf("after RemovePage(%d): current page = %v, %v, want %q", step.remove, page, err, step.title)
+		}
+	}
+	if want := [][2]int{{2, 1}, {1, 0}}; !reflect.DeepEqual(changes, want) {
+		t.Fatalf("OnPageChange calls = %v, want %v", changes, want)
+	}
+
+	if err := p.RemovePage(0); err != nil {
+		t.Fatalf("RemovePage(0): %v", err)
+	}
+	if got, index := p.PageCount(), p.CurrentIndex(); got != 0 || index != 0 {
+		t.Fatalf("after removing every page: PageCount = %d, CurrentIndex = %d, want 0, 0", got, index)
+	}
+	if err := p.RemovePage(0); err != dgwidgets.ErrIndexOutOfBounds {
+		t.Fatalf("RemovePage on no pages = %v, want ErrIndexOutOfBounds", err)
+	}
+}
+
+// footers returns the footer texts of the paginator's pages
+func footers(p *dgwidgets.Paginator) []string {
+	var texts []string
+	for _, page := range p.Pages {
+		texts = append(texts, page.Footer.Text)
+	}
+	return texts
+}
+
+func TestReorderSetsFootersAgain(t *testing.T) {
+	p, _ := newPaginator(0)
+	for _, name := range []string{"a", "b", "c"} {
+		p.Add(&discordgo.MessageEmbed{Title: name, Footer: &discordgo.MessageEmbedFooter{Text: name}})
+	}
+	p.SetPageFootersFormat("{current}/{total} {existing}")
+	p.SetLoopAnchors(2)
+
+	if err := p.Goto(1); err != nil {
+		t.Fatalf("Goto: %v", err)
+	}
+	if err := p.Reverse(); err != nil {
+		t.Fatalf("Reverse: %v", err)
+	}
+	if want := []string{"1/3 c", "2/3 b", "3/3 a"}; !reflect.DeepEqual(footers(p), want) {
+		t.Fatalf("footers after Reverse = %q, want %q", footers(p), want)
+	}
+	if got := p.CurrentIndex(); got != 0 {
+		t.Fatalf("CurrentIndex after Reverse = %d, want 0", got)
+	}
+
+	// The anchor moved to the first page along with "c"
+	p.Loop = true
+	if err := p.PreviousPage(); err != dgwidgets.ErrIndexOutOfBounds {
+		t.Fatalf("PreviousPage from the anchor = %v, want ErrIndexOutOfBounds", err)
+	}
+
+	if err := p.Shuffle(1); err != nil {
+		t.Fatalf("Shuffle: %v", err)
+	}
+	for index, page := range p.Pages {
+		if want := strconv.Itoa(index+1) + "/3 " + page.Title; page.Footer.Text != want {
+			t.Fatalf("footer of page %q after Shuffle = %q, want %q", page.Title, page.Footer.Text, want)
+		}
+	}
+
+	// Footers also follow removed pages
+	if err := p.RemovePage(0); err != nil {
+		t.Fatalf("RemovePage: %v", err)
+	}
+	for index, page := range p.Pages {
+		if want := strconv.Itoa(index+1) + "/2 " + page.Title; page.Footer.Text != want {
+			t.Fatalf("footer of page %q after RemovePage = %q, want %q", page.Title, page.Footer.Text, want)
+		}
+	}
+}
+
+func TestCloneCopiesConfiguration(t *testing.T) {
+	template, _ := newPaginator(3)
+	template.Loop = true
+	template.AllowedUsers = []string{"user"}
+	voted := make(chan string, 1)
+	template.Widget.Handle("👍", func(_ *dgwidgets.Widget, r *discordgo.MessageReaction) {
+		voted <- r.UserID
+	})
+	spawn(t, template)
+	if err := template.Goto(2); err != nil {
+		t.Fatalf("Goto: %v", err)
+	}
+
+	ses := dgtest.NewFakeSession("bot")
+	c := template.Clone(ses, "other")
+	if got := c.PageCount(); got != 0 {
+		t.Fatalf("clone has %d pages, want none", got)
+	}
+	c.Add(&discordgo.MessageEmbed{Title: "x"}, &discordgo.MessageEmbed{Title: "y"})
+	msg := spawn(t, c)
+	if msg.ChannelID != "other" {
+		t.Fatalf("clone spawned on %q, want %q", msg.ChannelID, "other")
+	}
+	if got := c.CurrentIndex(); got != 0 {
+		t.Fatalf("clone CurrentIndex = %d, want 0", got)
+	}
+
+	// The controls are added once, followed by the custom handler
+	want := []string{dgwidgets.NavBeginning, dgwidgets.NavLeft, dgwidgets.NavRight, dgwidgets.NavEnd, dgwidgets.NavNumbers, "👍"}
+	waitFor(t, "the reactions", func() bool { return len(ses.MessageReactions(msg.ID)) == len(want) })
+	if got := ses.MessageReactions(msg.ID); !reflect.DeepEqual(got, want) {
+		t.Fatalf("clone reactions = %q, want %q", got, want)
+	}
+
+	// Loop and AllowedUsers carry over
+	ses.React(msg.ChannelID, msg.ID, "stranger", dgwidgets.NavRight)
+	ses.React(msg.ChannelID, msg.ID, "user", dgwidgets.NavLeft)
+	waitFor(t, "the wrap to the last page", func() bool {
+		edited, _ := ses.Message(msg.ID)
+		return edited.Embeds[0].Title == "y"
+	})
+	if got := c.CurrentIndex(); got != 1 {
+		t.Fatalf("clone CurrentIndex = %d, want 1", got)
+	}
+	ses.React(msg.ChannelID, msg.ID, "user", "👍")
+	if userID := <-voted; userID != "user" {
+		t.Fatalf("custom handler called for %q, want user", userID)
+	}
+}
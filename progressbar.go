@@ -0,0 +1,41 @@
+package dgwidgets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProgressBarStyle are the characters a progress bar is drawn with
+type ProgressBarStyle struct {
+	Filled string
+	Empty  string
+	Knob   string
+}
+
+// DefaultProgressBarStyle returns the default progress bar style
+func DefaultProgressBarStyle() ProgressBarStyle {
+	return ProgressBarStyle{
+		Filled: "▬",
+		Empty:  "▬",
+		Knob:   "🔘",
+	}
+}
+
+// progressBar returns a bar of width characters with the knob at
+// the position of index in total, followed by "index/total",
+// e.g. "▬▬🔘▬▬ 3/6"
+func progressBar(index, total, width int, style ProgressBarStyle) string {
+	if width < 1 {
+		width = 1
+	}
+	knob := 0
+	if total > 1 {
+		knob = index * (width - 1) / (total - 1)
+	}
+	return fmt.Sprintf("%s%s%s %d/%d",
+		strings.Repeat(style.Filled, knob),
+		style.Knob,
+		strings.Repeat(style.Empty, width-knob-1),
+		index+1, total,
+	)
+}
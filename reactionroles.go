@@ -0,0 +1,59 @@
+package dgwidgets
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// ReactionRoles is a message that grants roles to users who react to it
+// and removes them when the reaction is removed.
+type ReactionRoles struct {
+	*Widget
+
+	// Roles binds emojis to role IDs
+	Roles map[string]string
+	// Only allow users to have one role of the set at a time
+	Exclusive bool
+}
+
+// NewReactionRoles returns a new ReactionRoles
+//    ses      : discordgo session
+//    channelID: channelID to spawn the message on
+//    embed    : embed describing the roles
+func NewReactionRoles(ses Sessioner, channelID string, embed *discordgo.MessageEmbed) *ReactionRoles {
+	rr := &ReactionRoles{
+		Widget: NewWidget(ses, channelID, embed),
+		Roles:  map[string]string{},
+	}
+	rr.DeleteReactions = false
+
+	return rr
+}
+
+// AddRole binds an emoji to a role
+//    emoji : The unicode value of the emoji, or a custom
+//            emoji formatted with FormatEmoji
+//    roleID: ID of the role to grant
+func (rr *ReactionRoles) AddRole(emoji, roleID string) error {
+	rr.Roles[emoji] = roleID
+	rr.HandleRemove(emoji, func(w *Widget, r *discordgo.MessageReaction) {
+		rr.Ses.GuildMemberRoleRemove(r.GuildID, r.UserID, rr.Roles[emoji])
+	})
+	return rr.Handle(emoji, func(w *Widget, r *discordgo.MessageReaction) {
+		rr.grant(emoji, r)
+	})
+}
+
+// grant adds the role of emoji to the user that reacted. In exclusive
+// mode the user's other roles and reactions of the set are removed.
+func (rr *ReactionRoles) grant(emoji string, r *discordgo.MessageReaction) {
+	if rr.Exclusive {
+		for other, roleID := range rr.Roles {
+			if other == emoji {
+				continue
+			}
+			rr.Ses.GuildMemberRoleRemove(r.GuildID, r.UserID, roleID)
+			rr.Ses.MessageReactionRemove(r.ChannelID, r.MessageID, reactionAPIName(other), r.UserID)
+		}
+	}
+	rr.Ses.GuildMemberRoleAdd(r.GuildID, r.UserID, rr.Roles[emoji])
+}
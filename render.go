@@ -0,0 +1,58 @@
+package dgwidgets
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// Render returns the messages the paginator would send for each of
+// its pages, with footers, titles and components applied as on Spawn,
+// without calling the Discord API. Useful to preview the pages or to
+// test how they are built.
+func (p *Paginator) Render() ([]*discordgo.MessageSend, error) {
+	total := p.PageCount()
+	if total == 0 {
+		return nil, ErrNoPages
+	}
+
+	mentions := p.Widget.AllowedMentions
+	if p.AllowedMentions != nil {
+		mentions = p.AllowedMentions
+	}
+	reference := p.Widget.ReplyTo
+	if p.ReplyTo != nil {
+		reference = p.ReplyTo
+	}
+	mentionRepliedUser := p.Widget.MentionRepliedUser || p.MentionRepliedUser
+
+	messages := make([]*discordgo.MessageSend, 0, total)
+	for index := 0; index < total; index++ {
+		msg := &discordgo.MessageSend{
+			AllowedMentions: mentions,
+			Flags:           p.messageFlags(),
+		}
+		setReply(msg, reference, mentionRepliedUser)
+		switch {
+		case p.componentsMode():
+			// The page is made of the components
+		case p.contentMode():
+			content, err := p.contentAt(index)
+			if err != nil {
+				return nil, wrapErr(err, "paginator: render page %d", index)
+			}
+			msg.Content = content
+		default:
+			page, err := p.renderPageAt(index)
+			if err != nil {
+				return nil, wrapErr(err, "paginator: render page %d", index)
+			}
+			msg.Embeds = []*discordgo.MessageEmbed{copyEmbed(page)}
+			msg.Content = p.StaticContent
+			msg.Files = p.pageFiles(index)
+		}
+		if p.UseButtons || p.UseSelectMenu || p.componentsMode() {
+			msg.Components = p.messageComponents(index)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
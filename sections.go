@@ -0,0 +1,94 @@
+package dgwidgets
+
+import (
+	"sort"
+)
+
+// section is a named group of pages starting at start
+type section struct {
+	name  string
+	start int
+}
+
+// AddSection adds a named section starting at the page at startIndex
+// and spanning the pages until the next section. Adding a section at
+// the start of an existing one renames it.
+//    name      : name of the section
+//    startIndex: index of the section's first page
+func (p *Paginator) AddSection(name string, startIndex int) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if startIndex < 0 || startIndex >= p.pageCount() {
+		return ErrIndexOutOfBounds
+	}
+	for i, s := range p.sections {
+		if s.start == startIndex {
+			p.sections[i].name = name
+			return nil
+		}
+	}
+	p.sections = append(p.sections, section{name: name, start: startIndex})
+	sort.Slice(p.sections, func(i, j int) bool {
+		return p.sections[i].start < p.sections[j].start
+	})
+	return nil
+}
+
+// NextSection jumps to the first page of the next section,
+// cycling back to the first section after the last one.
+// Returns ErrIndexOutOfBounds when there are no sections.
+func (p *Paginator) NextSection() error {
+	return p.changePage(func() error {
+		if len(p.sections) == 0 {
+			return ErrIndexOutOfBounds
+		}
+		index := p.Index.get()
+		next := p.sections[0].start
+		for _, s := range p.sections {
+			if s.start > index {
+				next = s.start
+				break
+			}
+		}
+		if next >= p.pageCount() {
+			return ErrIndexOutOfBounds
+		}
+		p.Index.Set(next)
+		return nil
+	})
+}
+
+// Section returns the name of the section of the current page,
+// or "" if the page isn't part of a section
+func (p *Paginator) Section() string {
+	p.Lock()
+	defer p.Unlock()
+	return p.sectionAt(p.Index.get())
+}
+
+// sectionAt returns the name of the section of the page at index while p is locked
+func (p *Paginator) sectionAt(index int) string {
+	name := ""
+	for _, s := range p.sections {
+		if s.start > index {
+			break
+		}
+		name = s.name
+	}
+	return name
+}
+
+// sectionName returns the name of the section of the page at index
+func (p *Paginator) sectionName(index int) string {
+	p.Lock()
+	defer p.Unlock()
+	return p.sectionAt(index)
+}
+
+// hasSections returns true if sections were added to the paginator
+func (p *Paginator) hasSections() bool {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.sections) > 0
+}
@@ -0,0 +1,73 @@
+package dgwidgets
+
+import (
+	"encoding/json"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// paginatorState is the serialized state of a Paginator,
+// its options are stored like PaginatorConfig
+type paginatorState struct {
+	PaginatorConfig
+
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id,omitempty"`
+
+	Pages    []*discordgo.MessageEmbed `json:"pages,omitempty"`
+	Contents []string                  `json:"contents,omitempty"`
+	Index    int                       `json:"index"`
+
+	AllowedUsers []string `json:"allowed_users,omitempty"`
+}
+
+// Export serializes the paginator's pages, current index,
+// channel and message IDs and options to JSON.
+// Callbacks, providers and the Errors channel aren't exported.
+func (p *Paginator) Export() ([]byte, error) {
+	state := paginatorState{
+		ChannelID: p.Widget.ChannelID,
+	}
+	if msg := p.Message(); msg != nil {
+		state.MessageID = msg.ID
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	state.PaginatorConfig = p.Config()
+	state.Pages = p.Pages
+	state.Contents = p.Contents
+	state.Index = p.Index.get()
+	state.AllowedUsers = p.AllowedUsers
+
+	return json.Marshal(state)
+}
+
+// RestorePaginator returns a paginator from data created by Export.
+// The restored paginator's Message returns the exported message,
+// call AttachToMessage with its ID to resume it.
+//    ses : discordgo session
+//    data: exported paginator
+func RestorePaginator(ses Sessioner, data []byte) (*Paginator, error) {
+	var state paginatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	p := NewPaginatorFromConfig(ses, state.ChannelID, state.PaginatorConfig)
+	p.Pages = state.Pages
+	if p.Pages == nil {
+		p.Pages = []*discordgo.MessageEmbed{}
+	}
+	p.Contents = state.Contents
+	p.Index.Set(state.Index)
+	p.AllowedUsers = state.AllowedUsers
+	if state.MessageID != "" {
+		p.Widget.Message = &discordgo.Message{
+			ID:        state.MessageID,
+			ChannelID: state.ChannelID,
+		}
+	}
+
+	return p, nil
+}
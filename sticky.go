@@ -0,0 +1,80 @@
+package dgwidgets
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// watchSticky reposts the paginator once enough messages were sent
+// below it. Returns a function that stops watching.
+func (p *Paginator) watchSticky() func() {
+	var (
+		mu        sync.Mutex
+		count     int
+		last      = time.Now()
+		reposting bool
+	)
+	return p.Ses.AddHandler(func(s *discordgo.Session, m *discordgo.MessageCreate) {
+		if m.ChannelID != p.Widget.ChannelID {
+			return
+		}
+		// Ignore messages sent by bot, including the reposts
+		if s != nil && s.State != nil && s.State.User != nil && m.Author != nil && s.State.User.ID == m.Author.ID {
+			return
+		}
+
+		mu.Lock()
+		count++
+		if reposting || count < p.stickyThreshold() || time.Since(last) < p.stickyInterval() {
+			mu.Unlock()
+			return
+		}
+		reposting = true
+		mu.Unlock()
+
+		err := p.repost()
+
+		mu.Lock()
+		reposting = false
+		count = 0
+		last = time.Now()
+		mu.Unlock()
+		p.reportError(err)
+	})
+}
+
+// repost sends the current page at the bottom of the channel
+// and deletes the previous message. The page is prepared from the
+// Widget's event loop so it doesn't change while the loop sends it.
+func (p *Paginator) repost() error {
+	return wrapErr(p.Widget.repostWith(func() error {
+		if err := p.prepareMessage(); err != nil {
+			return err
+		}
+		if p.UseButtons || p.UseSelectMenu || p.componentsMode() {
+			components := p.messageComponents(p.CurrentIndex())
+			p.Widget.Lock()
+			p.Widget.Components = components
+			p.Widget.Unlock()
+		}
+		return nil
+	}), "paginator: repost")
+}
+
+// stickyThreshold returns p.StickyThreshold, or 5 when it isn't set
+func (p *Paginator) stickyThreshold() int {
+	if p.StickyThreshold <= 0 {
+		return 5
+	}
+	return p.StickyThreshold
+}
+
+// stickyInterval returns p.StickyInterval, or 30 seconds when it isn't set
+func (p *Paginator) stickyInterval() time.Duration {
+	if p.StickyInterval <= 0 {
+		return 30 * time.Second
+	}
+	return p.StickyInterval
+}
@@ -0,0 +1,43 @@
+package dgwidgets
+
+// StopReason describes why a widget stopped
+type StopReason int
+
+// stop reasons
+const (
+	// StopTimeout means the widget timed out
+	StopTimeout StopReason = iota
+	// StopUser means the widget was stopped with Stop, a stop control or w.Close
+	StopUser
+	// StopContextCancelled means the context passed to Spawn was done
+	StopContextCancelled
+	// StopError means the widget failed to spawn
+	StopError
+	// StopMessageDeleted means the widget's message was deleted externally
+	StopMessageDeleted
+	// StopMaxLifetime means the widget reached its MaxLifetime
+	StopMaxLifetime
+	// StopChannelDeleted means the widget's channel was deleted
+	StopChannelDeleted
+)
+
+// String returns the name of the stop reason
+func (r StopReason) String() string {
+	switch r {
+	case StopTimeout:
+		return "timeout"
+	case StopUser:
+		return "user stop"
+	case StopContextCancelled:
+		return "context cancelled"
+	case StopError:
+		return "error"
+	case StopMessageDeleted:
+		return "message deleted"
+	case StopMaxLifetime:
+		return "max lifetime"
+	case StopChannelDeleted:
+		return "channel deleted"
+	}
+	return "unknown"
+}
@@ -1,25 +1,133 @@
 package dgwidgets
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/bwmarrin/discordgo"
 )
 
-// NextMessageCreateC returns a channel for the next MessageCreate event
-func nextMessageCreateC(s *discordgo.Session) chan *discordgo.MessageCreate {
-	out := make(chan *discordgo.MessageCreate)
-	s.AddHandlerOnce(func(_ *discordgo.Session, e *discordgo.MessageCreate) {
-		out <- e
-	})
-	return out
+// discord embed limits
+const (
+	embedTitleLimit       = 256
+	embedDescriptionLimit = 4096
+	embedFieldLimit       = 25
+	embedFieldNameLimit   = 256
+	embedFieldValueLimit  = 1024
+	embedFooterLimit      = 2048
+	embedAuthorLimit      = 256
+	embedTotalLimit       = 6000
+)
+
+// containsString returns true if s is in list
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isForbidden returns true if err is a 403 response of the Discord API
+func isForbidden(err error) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusForbidden
+}
+
+// parsePageNumber parses a page number typed by a user, ignoring
+// surrounding whitespace and digit grouping separators such as in
+// "1,000" and accepting full-width and Arabic-Indic digits
+func parsePageNumber(s string) (int, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '０' && r <= '９':
+			r = '0' + r - '０'
+		case r >= '٠' && r <= '٩':
+			r = '0' + r - '٠'
+		case r >= '۰' && r <= '۹':
+			r = '0' + r - '۰'
+		case strings.ContainsRune(",.'_ \u00a0\u202f，．", r):
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strconv.Atoi(b.String())
+}
+
+// rateLimitRetryAfter returns how long to wait before retrying
+// if err is a 429 response of the Discord API
+func rateLimitRetryAfter(err error) (time.Duration, bool) {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+		return rateLimitErr.RetryAfter, true
+	}
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Response == nil || restErr.Response.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(restErr.Response.Header.Get("Retry-After"), 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	return time.Second, true
+}
+
+// copyEmbed returns a deep copy of embed
+func copyEmbed(embed *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	if embed == nil {
+		return nil
+	}
+	c := *embed
+	if embed.Footer != nil {
+		footer := *embed.Footer
+		c.Footer = &footer
+	}
+	if embed.Image != nil {
+		image := *embed.Image
+		c.Image = &image
+	}
+	if embed.Thumbnail != nil {
+		thumbnail := *embed.Thumbnail
+		c.Thumbnail = &thumbnail
+	}
+	if embed.Video != nil {
+		video := *embed.Video
+		c.Video = &video
+	}
+	if embed.Provider != nil {
+		provider := *embed.Provider
+		c.Provider = &provider
+	}
+	if embed.Author != nil {
+		author := *embed.Author
+		c.Author = &author
+	}
+	if embed.Fields != nil {
+		c.Fields = make([]*discordgo.MessageEmbedField, len(embed.Fields))
+		for i, field := range embed.Fields {
+			if field != nil {
+				f := *field
+				c.Fields[i] = &f
+			}
+		}
+	}
+	return &c
 }
 
-// NextMessageReactionAddC returns a channel for the next MessageReactionAdd event
-func nextMessageReactionAddC(s *discordgo.Session) chan *discordgo.MessageReactionAdd {
-	out := make(chan *discordgo.MessageReactionAdd)
-	s.AddHandlerOnce(func(_ *discordgo.Session, e *discordgo.MessageReactionAdd) {
-		out <- e
-	})
-	return out
+// interactionUserID returns the ID of the user that triggered the interaction
+func interactionUserID(i *discordgo.Interaction) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
 }
 
 // EmbedsFromString splits a string into a slice of MessageEmbeds.
@@ -44,3 +152,27 @@ func EmbedsFromString(txt string, chunkLen int) []*discordgo.MessageEmbed {
 	}
 	return embeds
 }
+
+// splitText splits txt into chunks of at most maxLen characters
+// without breaking words, unless a single word is longer than maxLen.
+//     txt   : text to split
+//     maxLen: maximum length of each chunk
+func splitText(txt string, maxLen int) []string {
+	var chunks []string
+	runes := []rune(strings.TrimSpace(txt))
+	for len(runes) > maxLen {
+		end := maxLen
+		for i := maxLen; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				end = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimRightFunc(string(runes[:end]), unicode.IsSpace))
+		runes = []rune(strings.TrimLeftFunc(string(runes[end:]), unicode.IsSpace))
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
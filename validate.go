@@ -0,0 +1,95 @@
+package dgwidgets
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// embedViolations returns the embed limits exceeded by embed
+func embedViolations(embed *discordgo.MessageEmbed) []string {
+	var violations []string
+	check := func(name, text string, limit int) int {
+		n := utf8.RuneCountInString(text)
+		if n > limit {
+			violations = append(violations, fmt.Sprintf("%s is %d characters long, the limit is %d", name, n, limit))
+		}
+		return n
+	}
+
+	total := check("title", embed.Title, embedTitleLimit)
+	total += check("description", embed.Description, embedDescriptionLimit)
+	if embed.Footer != nil {
+		total += check("footer", embed.Footer.Text, embedFooterLimit)
+	}
+	if embed.Author != nil {
+		total += check("author", embed.Author.Name, embedAuthorLimit)
+	}
+	if len(embed.Fields) > embedFieldLimit {
+		violations = append(violations, fmt.Sprintf("has %d fields, the limit is %d", len(embed.Fields), embedFieldLimit))
+	}
+	for i, field := range embed.Fields {
+		if field == nil {
+			continue
+		}
+		total += check(fmt.Sprintf("field %d name", i), field.Name, embedFieldNameLimit)
+		total += check(fmt.Sprintf("field %d value", i), field.Value, embedFieldValueLimit)
+	}
+	if total > embedTotalLimit {
+		violations = append(violations, fmt.Sprintf("is %d characters long in total, the limit is %d", total, embedTotalLimit))
+	}
+	return violations
+}
+
+// ValidatePages checks every page against Discord's embed limits and
+// returns an error wrapping ErrPageTooLarge for each page exceeding them.
+// Pages of a PageProvider aren't checked.
+func (p *Paginator) ValidatePages() []error {
+	p.Lock()
+	defer p.Unlock()
+
+	var errs []error
+	for index, page := range p.Pages {
+		if page == nil {
+			continue
+		}
+		if violations := embedViolations(page); len(violations) > 0 {
+			errs = append(errs, fmt.Errorf("%w: page %d: %s", ErrPageTooLarge, index, strings.Join(violations, ", ")))
+		}
+	}
+	return errs
+}
+
+// validateEmojis checks that the custom emojis of the paginator's
+// controls and handlers exist in p.EmojiGuildID
+func (p *Paginator) validateEmojis() error {
+	if p.EmojiGuildID == "" {
+		return nil
+	}
+	nav := p.navEmojis()
+	emojis := append([]string{nav.Beginning, nav.Left, nav.Right, nav.End, nav.Numbers}, p.Widget.Keys...)
+
+	var available map[string]bool
+	for _, emoji := range emojis {
+		_, id, _ := parseEmoji(emoji)
+		if id == "" {
+			continue
+		}
+		if available == nil {
+			guildEmojis, err := p.Ses.GuildEmojis(p.EmojiGuildID)
+			if err != nil {
+				return wrapErr(err, "paginator: get emojis of guild %s", p.EmojiGuildID)
+			}
+			available = map[string]bool{}
+			for _, e := range guildEmojis {
+				available[e.ID] = true
+			}
+		}
+		if !available[id] {
+			return wrapErr(ErrEmojiNotFound, "paginator: emoji %s in guild %s", emoji, p.EmojiGuildID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package dgwidgets
+
+// ViewerMode turns a paginator into a slideshow controlled by presenters.
+// The controls are shown to everyone, but only the reactions of the
+// presenters and AllowedUsers are honored. All other reactions are removed,
+// which requires the Manage Messages permission.
+type ViewerMode struct {
+	// Users presenting the pages. Spawn returns ErrNoPresenters
+	// when there is neither a presenter nor an allowed user.
+	Presenters []string
+}
+
+// applyViewerMode restricts the Widget to the presenters
+func (p *Paginator) applyViewerMode() {
+	if p.ViewerMode == nil {
+		return
+	}
+	p.Widget.UserWhitelist = append(append([]string(nil), p.AllowedUsers...), p.ViewerMode.Presenters...)
+	p.Widget.RemoveUnauthorizedReactions = true
+	p.Widget.StripForeignReactions = true
+}
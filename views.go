@@ -0,0 +1,159 @@
+package dgwidgets
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// viewExpiry is how long a user's view is kept without being used.
+// Interaction tokens expire after 15 minutes.
+const viewExpiry = 15 * time.Minute
+
+// view is a user's own position in a paginator with PerUserViews
+type view struct {
+	index    int
+	lastUsed time.Time
+}
+
+// isViewControl returns true if the custom ID belongs to a
+// control that moves a user's view
+func isViewControl(customID string) bool {
+	switch customID {
+	case ComponentBeginning, ComponentPrevious, ComponentNext, ComponentEnd:
+		return true
+	}
+	return false
+}
+
+// viewComponents returns the navigation buttons of a user's view.
+// Their custom IDs are suffixed with the paginator's message ID so
+// interactions on the ephemeral view messages can be matched to it.
+func (p *Paginator) viewComponents(messageID string) []discordgo.MessageComponent {
+	nav := p.navEmojis()
+	buttons := []discordgo.MessageComponent{}
+	for _, control := range []struct{ emoji, customID string }{
+		{nav.Beginning, ComponentBeginning},
+		{nav.Left, ComponentPrevious},
+		{nav.Right, ComponentNext},
+		{nav.End, ComponentEnd},
+	} {
+		if control.emoji != "" {
+			buttons = append(buttons, navButton(control.emoji, control.customID+":"+messageID))
+		}
+	}
+	if len(buttons) == 0 {
+		return []discordgo.MessageComponent{}
+	}
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// moveView returns the index a view at index moves to with the control
+func (p *Paginator) moveView(index int, customID string) int {
+	last := p.PageCount() - 1
+	switch customID {
+	case ComponentBeginning:
+		index = 0
+	case ComponentPrevious:
+		index--
+		if index < 0 {
+			index = 0
+			if p.Loop {
+				index = last
+			}
+		}
+	case ComponentNext:
+		index++
+		if index > last {
+			index = last
+			if p.Loop {
+				index = 0
+			}
+		}
+	case ComponentEnd:
+		index = last
+	}
+	return index
+}
+
+// handleViewInteraction moves the view of the user that used a
+// navigation button on the paginator's message or on their view,
+// responding with their view as an ephemeral message.
+func (p *Paginator) handleViewInteraction(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+	msg := p.Message()
+	if msg == nil || i.Type != discordgo.InteractionMessageComponent || i.Message == nil {
+		return
+	}
+	customID := i.MessageComponentData().CustomID
+	onView := strings.HasSuffix(customID, ":"+msg.ID)
+	customID = strings.TrimSuffix(customID, ":"+msg.ID)
+	if !isViewControl(customID) || (!onView && i.Message.ID != msg.ID) {
+		return
+	}
+	userID := interactionUserID(i.Interaction)
+	if !p.Widget.isUserAllowed(userID) {
+		p.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+		return
+	}
+	p.Widget.markActive()
+
+	p.Lock()
+	if p.views == nil {
+		// The paginator stopped
+		p.Unlock()
+		return
+	}
+	now := time.Now()
+	for id, v := range p.views {
+		if now.Sub(v.lastUsed) > viewExpiry {
+			delete(p.views, id)
+		}
+	}
+	v, ok := p.views[userID]
+	if !ok {
+		v = &view{index: p.Index.get()}
+		p.views[userID] = v
+	}
+	index := v.index
+	p.Unlock()
+
+	index = p.moveView(index, customID)
+
+	p.Lock()
+	v.index = index
+	v.lastUsed = now
+	p.Unlock()
+
+	data := &discordgo.InteractionResponseData{
+		Components: p.viewComponents(msg.ID),
+	}
+	if p.contentMode() {
+		content, err := p.contentAt(index)
+		if err != nil {
+			p.reportError(err)
+			return
+		}
+		data.Content = content
+	} else {
+		page, err := p.renderPageAt(index)
+		if err != nil {
+			p.reportError(err)
+			return
+		}
+		data.Embeds = []*discordgo.MessageEmbed{page}
+	}
+
+	// Open a new view when used on the paginator's message
+	resp := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	}
+	if !onView {
+		resp.Type = discordgo.InteractionResponseChannelMessageWithSource
+		data.Flags = discordgo.MessageFlagsEphemeral
+	}
+	p.reportError(wrapErr(p.Ses.InteractionRespond(i.Interaction, resp), "paginator: respond with view of user %s", userID))
+}
@@ -1,65 +1,160 @@
 package dgwidgets
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// wrapErr prefixes err with the formatted context, keeping
+// it matchable with errors.Is. Returns nil if err is nil.
+func wrapErr(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf(format+": %w", append(args, err)...)
+}
+
 // error vars
 var (
 	ErrAlreadyRunning   = errors.New("err: Widget already running")
 	ErrIndexOutOfBounds = errors.New("err: Index is out of bounds")
 	ErrNilMessage       = errors.New("err: Message is nil")
 	ErrNilEmbed         = errors.New("err: embed is nil")
+	ErrNoPages          = errors.New("err: Paginator has no pages")
 	ErrNotRunning       = errors.New("err: not running")
 	ErrTickerNotSet     = errors.New("err: Timeout ticker is not set")
+	ErrConfirmTimeout   = errors.New("err: Confirm timed out")
+	ErrMenuTimeout      = errors.New("err: Menu timed out")
+	ErrNoOptions        = errors.New("err: Menu has no options")
+	ErrInputTimeout     = errors.New("err: Input timed out")
+	ErrReactionTimeout  = errors.New("err: Reaction timed out")
+	ErrCannotRepost     = errors.New("err: Interaction responses can't be reposted")
+	ErrEmojiNotFound    = errors.New("err: Emoji not found in guild")
+	ErrPageTooLarge     = errors.New("err: Page exceeds Discord's embed limits")
+	ErrNoPresenters     = errors.New("err: ViewerMode has no presenters")
 )
 
 // WidgetHandler ...
 type WidgetHandler func(*Widget, *discordgo.MessageReaction)
 
+// SessionHandler is a WidgetHandler that also receives the discordgo session
+type SessionHandler func(*discordgo.Session, *Widget, *discordgo.MessageReaction)
+
+// ComponentHandler is called when a message component (e.g. a button)
+// attached to the widget is used.
+type ComponentHandler func(*Widget, *discordgo.InteractionCreate)
+
+// Middleware is called with every reaction on the widget's message
+// before it is dispatched to a handler. Returning false stops the
+// reaction from being dispatched.
+type Middleware func(*Widget, *discordgo.MessageReaction) bool
+
 // Widget is a message embed with reactions for buttons.
 // Accepts custom handlers for reactions.
 type Widget struct {
 	sync.Mutex
 	Embed     *discordgo.MessageEmbed
+	Content   string
 	Message   *discordgo.Message
-	Ses       *discordgo.Session
+	Ses       Sessioner
 	ChannelID string
 	Timeout   time.Duration
-	Close     chan bool
+	// Stop after this much time without an action on the widget.
+	// Timeout still applies as the total lifetime, so the idle
+	// timeout never exceeds it unless RefreshAfterAction is set.
+	IdleTimeout time.Duration
+	// Stop after this much time since the message was sent, even
+	// when actions keep refreshing the timeouts
+	MaxLifetime time.Duration
+	Close       chan bool
 
 	// Handlers binds emoji names to functions
 	Handlers map[string]WidgetHandler
 	// keys stores the handlers keys in the order they were added
 	Keys []string
 
+	// Components are sent along with the widget's message
+	Components []discordgo.MessageComponent
+	// ComponentHandlers binds component custom IDs to functions
+	ComponentHandlers map[string]ComponentHandler
+	// Don't add reaction buttons or dispatch reaction events
+	DisableReactions bool
+
+	// Interaction to respond to with the widget's message instead of
+	// sending it to ChannelID. Reactions aren't added to interaction
+	// responses and edits stop working once the interaction token
+	// expires after 15 minutes.
+	Interaction *discordgo.Interaction
+	// Send the interaction response as an ephemeral message
+	Ephemeral bool
+
+	// Mentions allowed to ping when the message is sent,
+	// nil allows all mentions in the message
+	AllowedMentions *discordgo.MessageAllowedMentions
+	// Flags of the sent message, e.g. discordgo.MessageFlagsSuppressEmbeds
+	Flags discordgo.MessageFlags
+	// Files attached to the sent message
+	Files []*discordgo.File
+	// Message to send the widget's message as a reply to
+	ReplyTo *discordgo.MessageReference
+	// Ping the author of the ReplyTo message
+	MentionRepliedUser bool
+
 	// Delete reactions after they are added
 	DeleteReactions bool
+	// Delay between adding each reaction button
+	ReactionAddDelay time.Duration
 	// Refresh timer after action on a widget
 	RefreshAfterAction bool
 	// Only allow listed users to use reactions.
 	UserWhitelist []string
+	// Remove reactions of users that aren't allowed to use the widget
+	RemoveUnauthorizedReactions bool
+	// Remove reactions with emojis that have no handler.
+	// Requires the Manage Messages permission, it is ignored without.
+	StripForeignReactions bool
+
+	running    bool
+	ticker     *time.Ticker
+	idleTimer  *time.Timer
+	onStart    func()
+	stopReason StopReason
+	bound      *discordgo.Message
+	middleware []Middleware
+	stopC      chan struct{}
+	// reposts receives repost requests for the event loop,
+	// which ends once loopDone is closed
+	reposts  chan repostRequest
+	loopDone chan struct{}
+	// activity resets the idle timeout from outside the event loop
+	activity chan struct{}
 
-	running bool
-	ticker  *time.Ticker
+	// removeHandlers binds emoji names to functions called when a reaction is removed
+	removeHandlers map[string]WidgetHandler
+
+	// firstRender is set while the message hasn't been edited since it was sent
+	firstRender bool
 }
 
 // NewWidget returns a pointer to a Widget object
 //    ses      : discordgo session
 //    channelID: channelID to spawn the widget on
-func NewWidget(ses *discordgo.Session, channelID string, embed *discordgo.MessageEmbed) *Widget {
+func NewWidget(ses Sessioner, channelID string, embed *discordgo.MessageEmbed) *Widget {
 	return &Widget{
-		ChannelID:       channelID,
-		Ses:             ses,
-		Keys:            []string{},
-		Handlers:        map[string]WidgetHandler{},
-		Close:           make(chan bool),
-		DeleteReactions: true,
-		Embed:           embed,
+		ChannelID:         channelID,
+		Ses:               ses,
+		Keys:              []string{},
+		Handlers:          map[string]WidgetHandler{},
+		ComponentHandlers: map[string]ComponentHandler{},
+		Close:             make(chan bool),
+		DeleteReactions:   true,
+		ReactionAddDelay:  250 * time.Millisecond,
+		Embed:             embed,
 	}
 }
 
@@ -79,127 +174,915 @@ func (w *Widget) isUserAllowed(userID string) bool {
 
 // Spawn spawns the widget in channel w.ChannelID
 func (w *Widget) Spawn() error {
-	if w.Running() {
+	return w.SpawnWithContext(context.Background())
+}
+
+// SpawnWithContext spawns the widget in channel w.ChannelID
+// and stops listening for events once ctx is done.
+//    ctx: context to stop the widget with
+func (w *Widget) SpawnWithContext(ctx context.Context) error {
+	return w.run(ctx, nil)
+}
+
+// AttachWithContext runs the widget on an existing message instead of
+// sending a new one, e.g. to resume a widget after a restart.
+// Reaction buttons are expected to already be on the message.
+//    ctx: context to stop the widget with
+//    msg: message to attach to
+func (w *Widget) AttachWithContext(ctx context.Context, msg *discordgo.Message) error {
+	if msg == nil {
+		return ErrNilMessage
+	}
+	return w.run(ctx, msg)
+}
+
+// run sends the widget's message, or uses attach when it is
+// not nil, and listens for events until the widget stops.
+func (w *Widget) run(ctx context.Context, attach *discordgo.Message) error {
+	// Check and set running at once so concurrent runs can't both start
+	w.Lock()
+	if w.running {
+		w.Unlock()
 		return ErrAlreadyRunning
 	}
 	w.running = true
+	w.Message = attach
+	stop := make(chan struct{})
+	w.stopC = stop
+	w.Unlock()
 	defer func() {
+		w.Lock()
 		w.running = false
+		w.stopC = nil
+		w.reposts = nil
+		w.loopDone = nil
+		w.activity = nil
+		w.Unlock()
 	}()
 
-	if w.Embed == nil {
+	if attach == nil && w.Embed == nil && w.Content == "" && len(w.Components) == 0 {
+		w.setStopReason(StopError)
 		return ErrNilEmbed
 	}
 
+	// Don't send the message when stopped before starting
+	if ctx.Err() != nil {
+		w.setStopReason(StopContextCancelled)
+		return nil
+	}
+
 	if w.Timeout != 0 {
 		w.ticker = time.NewTicker(w.Timeout)
 		defer w.ticker.Stop()
 	}
 
 	// Create initial message.
-	msg, err := w.Ses.ChannelMessageSendEmbed(w.ChannelID, w.Embed)
-	if err != nil {
-		return err
+	msg := attach
+	if msg == nil {
+		var err error
+		if w.bound != nil {
+			msg, err = w.edit(w.bound)
+		} else {
+			msg, err = w.send()
+		}
+		if err != nil {
+			w.setStopReason(StopError)
+			return err
+		}
 	}
+	w.Lock()
 	w.Message = msg
+	w.firstRender = true
+	w.Unlock()
+
+	if w.onStart != nil {
+		w.onStart()
+	}
+
+	// Add reaction buttons while listening for events, waiting for
+	// them to finish before returning so they can be cleaned up.
+	done := make(chan struct{})
+	var adding sync.WaitGroup
+	if !w.DisableReactions && w.Interaction == nil && attach == nil {
+		adding.Add(1)
+		go w.addReactions(msg, w.keys(), done, &adding)
+	}
+	defer adding.Wait()
+	defer close(done)
+
+	reposts := make(chan repostRequest)
+	activity := make(chan struct{}, 1)
+	w.Lock()
+	w.reposts = reposts
+	w.loopDone = done
+	w.activity = activity
+	w.Unlock()
+
+	// Listen for reactions
+	reactions := make(chan *discordgo.MessageReaction)
+	removeReactionHandler := w.Ses.AddHandler(func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		// Ignore reactions sent by bot
+		if s.State != nil && s.State.User != nil && s.State.User.ID == r.UserID {
+			return
+		}
+		select {
+		case reactions <- r.MessageReaction:
+		case <-done:
+		}
+	})
+	defer removeReactionHandler()
+
+	// Listen for reactions being removed
+	removed := make(chan *discordgo.MessageReaction)
+	removeReactionRemoveHandler := w.Ses.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionRemove) {
+		select {
+		case removed <- r.MessageReaction:
+		case <-done:
+		}
+	})
+	defer removeReactionRemoveHandler()
+
+	// Listen for the message being deleted
+	deleted := make(chan string)
+	removeDeleteHandler := w.Ses.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDelete) {
+		select {
+		case deleted <- m.ID:
+		case <-done:
+		}
+	})
+	defer removeDeleteHandler()
+	removeBulkDeleteHandler := w.Ses.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+		for _, id := range m.Messages {
+			select {
+			case deleted <- id:
+			case <-done:
+				return
+			}
+		}
+	})
+	defer removeBulkDeleteHandler()
+
+	// Listen for the channel being deleted
+	channelDeleted := make(chan string)
+	removeChannelDeleteHandler := w.Ses.AddHandler(func(_ *discordgo.Session, c *discordgo.ChannelDelete) {
+		select {
+		case channelDeleted <- c.ID:
+		case <-done:
+		}
+	})
+	defer removeChannelDeleteHandler()
+	removeThreadDeleteHandler := w.Ses.AddHandler(func(_ *discordgo.Session, c *discordgo.ThreadDelete) {
+		select {
+		case channelDeleted <- c.ID:
+		case <-done:
+		}
+	})
+	defer removeThreadDeleteHandler()
+
+	// Listen for component interactions
+	interactions := make(chan *discordgo.InteractionCreate)
+	if len(w.ComponentHandlers) > 0 {
+		removeHandler := w.Ses.AddHandler(func(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+			select {
+			case interactions <- i:
+			case <-done:
+			}
+		})
+		defer removeHandler()
+	}
 
-	// Add reaction buttons
-	for _, v := range w.Keys {
-		w.Ses.MessageReactionAdd(w.Message.ChannelID, w.Message.ID, v)
+	// Navigation timeout enabled
+	var timeout <-chan time.Time
+	if w.ticker != nil {
+		timeout = w.ticker.C
+	}
+
+	// Idle timeout enabled
+	var idleTimeout <-chan time.Time
+	if w.IdleTimeout != 0 {
+		w.idleTimer = time.NewTimer(w.IdleTimeout)
+		defer w.idleTimer.Stop()
+		idleTimeout = w.idleTimer.C
+	}
+
+	// Lifetime cap enabled, never reset by actions
+	var maxLifetime <-chan time.Time
+	if w.MaxLifetime != 0 {
+		lifetime := time.NewTimer(w.MaxLifetime)
+		defer lifetime.Stop()
+		maxLifetime = lifetime.C
 	}
 
 	var reaction *discordgo.MessageReaction
 	for {
-		// Navigation timeout enabled
-		if w.Timeout != 0 {
-			select {
-			case k := <-nextMessageReactionAddC(w.Ses):
-				reaction = k.MessageReaction
-			case <-w.ticker.C:
-				return nil
-			case <-w.Close:
+		select {
+		case reaction = <-reactions:
+		case r := <-removed:
+			if w.DisableReactions || r.MessageID != w.Message.ID {
+				continue
+			}
+			if v, ok := handlerIn(w.removeHandlers, r.Emoji); ok && w.isUserAllowed(r.UserID) {
+				w.resetIdleTimer()
+				go v(w, r)
+			}
+			continue
+		case req := <-reposts:
+			req.err <- w.repost(req.prepare, done, &adding)
+			continue
+		case <-activity:
+			w.resetIdleTimer()
+			continue
+		case i := <-interactions:
+			if w.handleInteraction(i) {
+				w.resetIdleTimer()
+			}
+			continue
+		case id := <-deleted:
+			if id == w.Message.ID {
+				w.setStopReason(StopMessageDeleted)
 				return nil
 			}
-		} else /*Navigation timeout not enabled*/ {
-			select {
-			case k := <-nextMessageReactionAddC(w.Ses):
-				reaction = k.MessageReaction
-			case <-w.Close:
+			continue
+		case id := <-channelDeleted:
+			if id == w.Message.ChannelID {
+				w.setStopReason(StopChannelDeleted)
 				return nil
 			}
+			continue
+		case <-timeout:
+			w.setStopReason(StopTimeout)
+			return nil
+		case <-idleTimeout:
+			w.setStopReason(StopTimeout)
+			return nil
+		case <-maxLifetime:
+			w.setStopReason(StopMaxLifetime)
+			return nil
+		case <-w.Close:
+			w.setStopReason(StopUser)
+			return nil
+		case <-stop:
+			w.setStopReason(StopUser)
+			return nil
+		case <-ctx.Done():
+			w.setStopReason(StopContextCancelled)
+			return nil
 		}
 
-		// Ignore reactions sent by bot
-		if reaction.MessageID != w.Message.ID || w.Ses.State.User.ID == reaction.UserID {
+		if w.DisableReactions || reaction.MessageID != w.Message.ID {
 			continue
 		}
 
-		if v, ok := w.Handlers[reaction.Emoji.Name]; ok {
-			if w.isUserAllowed(reaction.UserID) {
-				go v(w, reaction)
-			}
+		// Middleware sees every reaction, including ones without a handler.
+		// A rejected reaction isn't dispatched but is still cleaned up.
+		allow := w.runMiddleware(reaction)
+		v, handled := w.handlerFor(reaction.Emoji)
+		if allow && handled && w.isUserAllowed(reaction.UserID) {
+			w.resetIdleTimer()
+			go v(w, reaction)
 		}
 
-		if w.DeleteReactions {
-			go func() {
-				if w.isUserAllowed(reaction.UserID) {
+		if w.DeleteReactions || w.RemoveUnauthorizedReactions || w.StripForeignReactions {
+			go func(reaction *discordgo.MessageReaction) {
+				allowed := w.isUserAllowed(reaction.UserID)
+				if (allowed && w.DeleteReactions) || (!allowed && w.RemoveUnauthorizedReactions) || (!handled && w.StripForeignReactions) {
 					time.Sleep(time.Millisecond * 250)
-					w.Ses.MessageReactionRemove(reaction.ChannelID, reaction.MessageID, reaction.Emoji.Name, reaction.UserID)
+					w.Ses.MessageReactionRemove(reaction.ChannelID, reaction.MessageID, reaction.Emoji.APIName(), reaction.UserID)
 				}
-			}()
+			}(reaction)
+		}
+	}
+}
+
+// addReactions adds the reaction buttons to msg, waiting
+// w.ReactionAddDelay between them. Stops early when done is closed
+// and marks added as done when finished.
+func (w *Widget) addReactions(msg *discordgo.Message, keys []string, done <-chan struct{}, added *sync.WaitGroup) {
+	defer added.Done()
+	for i, v := range keys {
+		if i > 0 && w.ReactionAddDelay > 0 {
+			select {
+			case <-time.After(w.ReactionAddDelay):
+			case <-done:
+				return
+			}
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+		w.Ses.MessageReactionAdd(msg.ChannelID, msg.ID, reactionAPIName(v))
+	}
+}
+
+// handlerFor returns the handler of the reacted emoji.
+// Custom emojis are matched by ID.
+func (w *Widget) handlerFor(emoji discordgo.Emoji) (WidgetHandler, bool) {
+	w.Lock()
+	defer w.Unlock()
+	return handlerIn(w.Handlers, emoji)
+}
+
+// hasHandler returns true if a handler is registered for the emoji name
+func (w *Widget) hasHandler(emojiName string) bool {
+	w.Lock()
+	defer w.Unlock()
+	_, ok := w.Handlers[emojiName]
+	return ok
+}
+
+// keys returns a copy of w.Keys, which handlers may change while the widget runs
+func (w *Widget) keys() []string {
+	w.Lock()
+	defer w.Unlock()
+	return append([]string{}, w.Keys...)
+}
+
+// handlerIn returns the handler of the reacted emoji in handlers
+func handlerIn(handlers map[string]WidgetHandler, emoji discordgo.Emoji) (WidgetHandler, bool) {
+	if emoji.ID == "" {
+		v, ok := handlers[emoji.Name]
+		return v, ok
+	}
+	for key, v := range handlers {
+		if _, id, _ := parseEmoji(key); id == emoji.ID {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// send creates the widget's message
+func (w *Widget) send() (*discordgo.Message, error) {
+	if w.Interaction != nil {
+		return w.sendInteraction()
+	}
+
+	w.Lock()
+	components := w.Components
+	w.Unlock()
+	data := &discordgo.MessageSend{
+		Content:         w.Content,
+		Components:      components,
+		AllowedMentions: w.AllowedMentions,
+		Flags:           w.Flags,
+		Files:           w.Files,
+	}
+	setReply(data, w.ReplyTo, w.MentionRepliedUser)
+	if w.Embed != nil {
+		data.Embeds = []*discordgo.MessageEmbed{w.Embed}
+	}
+	defer lockChannel(w.ChannelID)()
+	msg, err := w.Ses.ChannelMessageSendComplex(w.ChannelID, data)
+	return msg, wrapErr(err, "widget: send message to channel %s", w.ChannelID)
+}
+
+// setReply makes data a reply to reference unless it is nil. The
+// reply only pings the replied user if mentionRepliedUser is set.
+//    data              : message to send
+//    reference         : message to reply to
+//    mentionRepliedUser: ping the author of the replied message
+func setReply(data *discordgo.MessageSend, reference *discordgo.MessageReference, mentionRepliedUser bool) {
+	if reference == nil {
+		return
+	}
+	var mentions discordgo.MessageAllowedMentions
+	if data.AllowedMentions != nil {
+		mentions = *data.AllowedMentions
+	} else {
+		mentions.Parse = []discordgo.AllowedMentionType{
+			discordgo.AllowedMentionTypeUsers,
+			discordgo.AllowedMentionTypeRoles,
+			discordgo.AllowedMentionTypeEveryone,
+		}
+	}
+	mentions.RepliedUser = mentionRepliedUser
+	data.Reference = reference
+	data.AllowedMentions = &mentions
+}
+
+// edit replaces the contents of msg with the widget's contents
+func (w *Widget) edit(msg *discordgo.Message) (*discordgo.Message, error) {
+	edit := discordgo.NewMessageEdit(msg.ChannelID, msg.ID).
+		SetContent(w.Content)
+	if w.Embed != nil {
+		edit.SetEmbed(w.Embed)
+	} else {
+		edit.Embeds = &[]*discordgo.MessageEmbed{}
+	}
+	edit.Components = &w.Components
+	edit.AllowedMentions = w.AllowedMentions
+	edit.Files = w.Files
+	if w.Flags&discordgo.MessageFlagsIsComponentsV2 != 0 {
+		edit.Flags = discordgo.MessageFlagsIsComponentsV2
+	}
+	defer lockChannel(edit.Channel)()
+	msg, err := w.Ses.ChannelMessageEditComplex(edit)
+	return msg, wrapErr(err, "widget: bind message %s", edit.ID)
+}
+
+// repostRequest asks the event loop to repost the message after
+// calling prepare, replying with the result on err
+type repostRequest struct {
+	prepare func() error
+	err     chan error
+}
+
+// Repost sends the widget's message again and deletes the old one,
+// e.g. to move it back to the bottom of the channel. The reaction
+// buttons are added to the new message in the background.
+// It must not be called from middleware, which blocks the repost.
+func (w *Widget) Repost() error {
+	return w.repostWith(nil)
+}
+
+// repostWith reposts the message from the event loop, calling
+// prepare first when it isn't nil to update the message's contents
+func (w *Widget) repostWith(prepare func() error) error {
+	w.Lock()
+	reposts, done := w.reposts, w.loopDone
+	w.Unlock()
+	if reposts == nil {
+		return ErrNotRunning
+	}
+	if w.Interaction != nil {
+		return ErrCannotRepost
+	}
+
+	req := repostRequest{prepare: prepare, err: make(chan error, 1)}
+	select {
+	case reposts <- req:
+	case <-done:
+		return ErrNotRunning
+	}
+	return <-req.err
+}
+
+// repost sends the message again from the event loop and deletes the
+// old one, adding the reaction buttons until done is closed
+func (w *Widget) repost(prepare func() error, done <-chan struct{}, adding *sync.WaitGroup) error {
+	if prepare != nil {
+		if err := prepare(); err != nil {
+			return err
+		}
+	}
+	msg, err := w.send()
+	if err != nil {
+		return err
+	}
+	w.Lock()
+	old := w.Message
+	w.Message = msg
+	w.firstRender = true
+	w.Unlock()
+
+	if old != nil {
+		unlock := lockChannel(old.ChannelID)
+		w.Ses.ChannelMessageDelete(old.ChannelID, old.ID)
+		unlock()
+	}
+	if !w.DisableReactions {
+		adding.Add(1)
+		go w.addReactions(msg, w.keys(), done, adding)
+	}
+	return nil
+}
+
+// BindMessage makes Spawn take over msg by editing it instead of
+// sending a new message.
+//    msg: message to bind the widget to
+func (w *Widget) BindMessage(msg *discordgo.Message) error {
+	if msg == nil {
+		return ErrNilMessage
+	}
+	w.bound = msg
+	w.ChannelID = msg.ChannelID
+	return nil
+}
+
+// sendInteraction responds to w.Interaction with the widget's message
+func (w *Widget) sendInteraction() (*discordgo.Message, error) {
+	flags := w.Flags
+	if w.Ephemeral {
+		flags |= discordgo.MessageFlagsEphemeral
+	}
+	err := w.Ses.InteractionRespond(w.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	})
+	if err != nil {
+		return nil, wrapErr(err, "widget: respond to interaction %s", w.Interaction.ID)
+	}
+
+	edit := &discordgo.WebhookEdit{
+		Components:      &w.Components,
+		AllowedMentions: w.AllowedMentions,
+		Files:           w.Files,
+	}
+	if w.Content != "" {
+		edit.Content = &w.Content
+	}
+	if w.Embed != nil {
+		edit.Embeds = &[]*discordgo.MessageEmbed{w.Embed}
+	}
+	msg, err := w.Ses.InteractionResponseEdit(w.Interaction, edit)
+	return msg, wrapErr(err, "widget: edit response to interaction %s", w.Interaction.ID)
+}
+
+// handleInteraction acknowledges a component interaction on the widget's
+// message and dispatches it to the matching component handler.
+// Returns true if a handler was called.
+func (w *Widget) handleInteraction(i *discordgo.InteractionCreate) bool {
+	if i.Type != discordgo.InteractionMessageComponent || i.Message == nil || i.Message.ID != w.Message.ID {
+		return false
+	}
+
+	// Leave interactions without a handler to be answered elsewhere,
+	// e.g. with a modal
+	v, ok := w.ComponentHandlers[i.MessageComponentData().CustomID]
+	if !ok {
+		return false
+	}
+
+	// Acknowledge the interaction, the message is edited separately.
+	w.Ses.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+
+	if w.isUserAllowed(interactionUserID(i.Interaction)) {
+		go v(w, i)
+		return true
+	}
+	return false
+}
+
+// resetIdleTimer restarts the idle timeout countdown
+func (w *Widget) resetIdleTimer() {
+	if w.idleTimer == nil {
+		return
+	}
+	if !w.idleTimer.Stop() {
+		select {
+		case <-w.idleTimer.C:
+		default:
 		}
 	}
+	w.idleTimer.Reset(w.IdleTimeout)
+}
+
+// markActive resets the idle timeout for uses of the widget that
+// aren't handled by the event loop. It does nothing when not running.
+func (w *Widget) markActive() {
+	w.Lock()
+	activity := w.activity
+	w.Unlock()
+	if activity == nil {
+		return
+	}
+	select {
+	case activity <- struct{}{}:
+	default:
+	}
 }
 
 // Handle adds a handler for the given emoji name
-//    emojiName: The unicode value of the emoji
+//    emojiName: The unicode value of the emoji, or a custom
+//               emoji formatted with FormatEmoji
 //    handler  : handler function to call when the emoji is clicked
 //               func(*Widget, *discordgo.MessageReaction)
 func (w *Widget) Handle(emojiName string, handler WidgetHandler) error {
+	w.Lock()
 	if _, ok := w.Handlers[emojiName]; !ok {
 		w.Keys = append(w.Keys, emojiName)
 		w.Handlers[emojiName] = handler
 	}
+	running, msg := w.running, w.Message
+	w.Unlock()
+
 	// if the widget is running, append the added emoji to the message.
-	if w.Running() && w.Message != nil {
-		return w.Ses.MessageReactionAdd(w.Message.ChannelID, w.Message.ID, emojiName)
+	if running && msg != nil {
+		err := w.Ses.MessageReactionAdd(msg.ChannelID, msg.ID, reactionAPIName(emojiName))
+		return wrapErr(err, "widget: add reaction %s to message %s", emojiName, msg.ID)
+	}
+	return nil
+}
+
+// HandleRemove adds a handler that is called when a user removes
+// their reaction with the given emoji, e.g. for toggles. It doesn't
+// add a reaction button, use Handle for that. Reactions removed by
+// DeleteReactions also call the handler, so it should usually be disabled.
+//    emojiName: The unicode value of the emoji, or a custom
+//               emoji formatted with FormatEmoji
+//    handler  : handler function to call when the emoji's reaction is removed
+//               func(*Widget, *discordgo.MessageReaction)
+func (w *Widget) HandleRemove(emojiName string, handler WidgetHandler) {
+	if w.removeHandlers == nil {
+		w.removeHandlers = map[string]WidgetHandler{}
+	}
+	w.removeHandlers[emojiName] = handler
+}
+
+// Use adds middleware that is called with every reaction before it
+// is dispatched, in the order they were added. The middleware is
+// called from the event loop, so it shouldn't block.
+//    mw: middleware to add
+func (w *Widget) Use(mw Middleware) {
+	w.middleware = append(w.middleware, mw)
+}
+
+// runMiddleware returns true if all middleware allow the reaction
+func (w *Widget) runMiddleware(r *discordgo.MessageReaction) bool {
+	for _, mw := range w.middleware {
+		if !mw(w, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveHandler removes the handler of the given emoji name
+// and, if the widget is running, its reaction from the message.
+//    emojiName: The unicode value of the emoji, or a custom
+//               emoji formatted with FormatEmoji
+func (w *Widget) RemoveHandler(emojiName string) error {
+	w.Lock()
+	if _, ok := w.Handlers[emojiName]; !ok {
+		w.Unlock()
+		return nil
+	}
+	delete(w.Handlers, emojiName)
+	for i, key := range w.Keys {
+		if key == emojiName {
+			w.Keys = append(w.Keys[:i], w.Keys[i+1:]...)
+			break
+		}
+	}
+	running, msg := w.running, w.Message
+	w.Unlock()
+
+	// if the widget is running, remove the emoji from the message.
+	if running && msg != nil {
+		err := w.Ses.MessageReactionRemove(msg.ChannelID, msg.ID, reactionAPIName(emojiName), "@me")
+		return wrapErr(err, "widget: remove reaction %s from message %s", emojiName, msg.ID)
 	}
 	return nil
 }
 
+// restoreHandlers adds removed handlers back in their place in order.
+// If the widget is running, the bot's reactions behind the first
+// restored one are added again after it to keep the order.
+//    handlers: handlers to add by emoji name
+//    order   : order of the widget's emojis
+func (w *Widget) restoreHandlers(handlers map[string]WidgetHandler, order []string) error {
+	w.Lock()
+	shown := map[string]bool{}
+	for _, key := range w.Keys {
+		shown[key] = true
+	}
+	for emojiName, handler := range handlers {
+		if _, ok := w.Handlers[emojiName]; !ok {
+			w.Keys = append(w.Keys, emojiName)
+			w.Handlers[emojiName] = handler
+		}
+	}
+	w.Keys = orderKeys(w.Keys, order)
+	keys := append([]string(nil), w.Keys...)
+	running, msg := w.running, w.Message
+	w.Unlock()
+
+	if !running || msg == nil {
+		return nil
+	}
+	first := len(keys)
+	for i, key := range keys {
+		if !shown[key] {
+			first = i
+			break
+		}
+	}
+	for _, key := range keys[first:] {
+		if !shown[key] {
+			continue
+		}
+		if err := w.Ses.MessageReactionRemove(msg.ChannelID, msg.ID, reactionAPIName(key), "@me"); err != nil {
+			return wrapErr(err, "widget: remove reaction %s from message %s", key, msg.ID)
+		}
+	}
+	for _, key := range keys[first:] {
+		if err := w.Ses.MessageReactionAdd(msg.ChannelID, msg.ID, reactionAPIName(key)); err != nil {
+			return wrapErr(err, "widget: add reaction %s to message %s", key, msg.ID)
+		}
+	}
+	return nil
+}
+
+// HandleWithSession adds a handler for the given emoji name that
+// receives the widget's session. The session is nil when w.Ses
+// isn't a *discordgo.Session.
+//    emojiName: The unicode value of the emoji, or a custom
+//               emoji formatted with FormatEmoji
+//    handler  : handler function to call when the emoji is clicked
+//               func(*discordgo.Session, *Widget, *discordgo.MessageReaction)
+func (w *Widget) HandleWithSession(emojiName string, handler SessionHandler) error {
+	return w.Handle(emojiName, func(w *Widget, r *discordgo.MessageReaction) {
+		s, _ := w.Ses.(*discordgo.Session)
+		handler(s, w, r)
+	})
+}
+
+// HandleComponent adds a handler for the given component custom ID
+//    customID: The custom ID of the component
+//    handler : handler function to call when the component is used
+//              func(*Widget, *discordgo.InteractionCreate)
+func (w *Widget) HandleComponent(customID string, handler ComponentHandler) {
+	if _, ok := w.ComponentHandlers[customID]; !ok {
+		w.ComponentHandlers[customID] = handler
+	}
+}
+
+// QueryInputOptions are the options of QueryInputWithOptions
+type QueryInputOptions struct {
+	// Channel to send the prompt to and read the response from,
+	// defaults to the widget's channel
+	ChannelID string
+	// User to read the response from, messages of other users are ignored
+	UserID string
+	// How long to wait for the user's response,
+	// defaults to 10 seconds when zero
+	Timeout time.Duration
+	// Delete the user's response after reading it
+	DeleteResponse bool
+}
+
 // QueryInput queries the user with ID `id` for input
 //    prompt : Question prompt
 //    userID : UserID to get message from
 //    timeout: How long to wait for the user's response
 func (w *Widget) QueryInput(prompt string, userID string, timeout time.Duration) (*discordgo.Message, error) {
-	msg, err := w.Ses.ChannelMessageSend(w.ChannelID, "<@"+userID+">,  "+prompt)
+	return w.QueryInputWithOptions(prompt, QueryInputOptions{
+		UserID:         userID,
+		Timeout:        timeout,
+		DeleteResponse: true,
+	})
+}
+
+// QueryInputWithOptions queries a user for input, only accepting
+// the user's messages in the channel. Other messages are ignored
+// and don't end the wait.
+//    prompt: Question prompt
+//    opts  : options of the query
+func (w *Widget) QueryInputWithOptions(prompt string, opts QueryInputOptions) (*discordgo.Message, error) {
+	channelID := opts.ChannelID
+	if channelID == "" {
+		channelID = w.ChannelID
+	}
+
+	unlock := lockChannel(channelID)
+	msg, err := w.Ses.ChannelMessageSend(channelID, "<@"+opts.UserID+">,  "+prompt)
+	unlock()
 	if err != nil {
-		return nil, err
+		return nil, wrapErr(err, "widget: send input prompt to channel %s", channelID)
 	}
 	defer func() {
+		defer lockChannel(msg.ChannelID)()
 		w.Ses.ChannelMessageDelete(msg.ChannelID, msg.ID)
 	}()
 
-	timeoutChan := make(chan int)
-	go func() {
-		time.Sleep(timeout)
-		timeoutChan <- 0
-	}()
+	done := make(chan struct{})
+	defer close(done)
+	responses := make(chan *discordgo.Message)
+	removeHandler := w.Ses.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageCreate) {
+		if m.ChannelID != channelID || m.Author == nil || m.Author.ID != opts.UserID {
+			return
+		}
+		select {
+		case responses <- m.Message:
+		case <-done:
+		}
+	})
+	defer removeHandler()
+
+	wait := opts.Timeout
+	if wait <= 0 {
+		wait = 10 * time.Second
+	}
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+
+	select {
+	case userMsg := <-responses:
+		if opts.DeleteResponse {
+			unlock := lockChannel(userMsg.ChannelID)
+			w.Ses.ChannelMessageDelete(userMsg.ChannelID, userMsg.ID)
+			unlock()
+		}
+		return userMsg, nil
+	case <-timeout.C:
+		return nil, ErrInputTimeout
+	}
+}
+
+// AwaitReaction adds the emojis to the widget's message and blocks
+// until the user reacts with one of them, returning the emoji.
+// When w.DeleteReactions is set, the added reactions and the user's
+// reaction are removed afterwards.
+//    emojis : emojis the user can answer with
+//    userID : user to wait for
+//    timeout: time to wait before returning ErrReactionTimeout
+func (w *Widget) AwaitReaction(emojis []string, userID string, timeout time.Duration) (string, error) {
+	msg := w.Message
+	if msg == nil {
+		return "", ErrNilMessage
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	reactions := make(chan *discordgo.MessageReaction)
+	removeHandler := w.Ses.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		if r.MessageID != msg.ID || r.UserID != userID {
+			return
+		}
+		select {
+		case reactions <- r.MessageReaction:
+		case <-done:
+		}
+	})
+	defer removeHandler()
+
+	for _, emoji := range emojis {
+		err := w.Ses.MessageReactionAdd(msg.ChannelID, msg.ID, reactionAPIName(emoji))
+		if err != nil {
+			return "", wrapErr(err, "widget: add reaction %s to message %s", emoji, msg.ID)
+		}
+	}
+	if w.DeleteReactions {
+		defer func() {
+			for _, emoji := range emojis {
+				w.Ses.MessageReactionRemove(msg.ChannelID, msg.ID, reactionAPIName(emoji), "@me")
+			}
+		}()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 
 	for {
 		select {
-		case userMsg := <-nextMessageCreateC(w.Ses):
-			if userMsg.Author.ID != userID {
-				continue
+		case r := <-reactions:
+			for _, emoji := range emojis {
+				if !emojiMatches(emoji, r.Emoji) {
+					continue
+				}
+				if w.DeleteReactions {
+					w.Ses.MessageReactionRemove(r.ChannelID, r.MessageID, r.Emoji.APIName(), r.UserID)
+				}
+				return emoji, nil
 			}
-			w.Ses.ChannelMessageDelete(userMsg.ChannelID, userMsg.ID)
-			return userMsg.Message, nil
-		case <-timeoutChan:
-			return nil, errors.New("timed out")
+		case <-timer.C:
+			return "", ErrReactionTimeout
 		}
 	}
 }
 
+// LastStopReason returns why the widget last stopped,
+// e.g. after Spawn returned
+func (w *Widget) LastStopReason() StopReason {
+	w.Lock()
+	defer w.Unlock()
+	return w.stopReason
+}
+
+// setStopReason sets the reason returned by LastStopReason
+func (w *Widget) setStopReason(reason StopReason) {
+	w.Lock()
+	w.stopReason = reason
+	w.Unlock()
+}
+
+// RequestStop stops the running widget without blocking, e.g. from
+// a handler after a final action. Unlike sending to w.Close it is safe
+// to call several times. Returns ErrNotRunning when the widget isn't
+// running or a stop was already requested.
+func (w *Widget) RequestStop() error {
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.running || w.stopC == nil {
+		return ErrNotRunning
+	}
+	close(w.stopC)
+	w.stopC = nil
+	return nil
+}
+
+// message returns w.Message, which is replaced when the widget is reposted
+func (w *Widget) message() *discordgo.Message {
+	w.Lock()
+	defer w.Unlock()
+	return w.Message
+}
+
 // Running returns w.running
 func (w *Widget) Running() bool {
 	w.Lock()
@@ -208,13 +1091,222 @@ func (w *Widget) Running() bool {
 	return running
 }
 
+// FirstRender returns true while the widget's message
+// hasn't been edited since it was sent, e.g. to tell the
+// initial render apart from updates after navigation.
+func (w *Widget) FirstRender() bool {
+	w.Lock()
+	defer w.Unlock()
+	return w.firstRender
+}
+
+// rendered clears the first render flag after a successful edit
+func (w *Widget) rendered(err error) {
+	if err != nil {
+		return
+	}
+	w.Lock()
+	w.firstRender = false
+	w.Unlock()
+}
+
 // UpdateEmbed updates the embed object and edits the original message
 //    embed: New embed object to replace w.Embed
 func (w *Widget) UpdateEmbed(embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
-	if w.Message == nil {
+	message := w.message()
+	if message == nil {
+		return nil, ErrNilMessage
+	}
+	if w.Interaction != nil {
+		msg, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{embed},
+		})
+		w.rendered(err)
+		return msg, wrapErr(err, "widget: update embed of interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(w.ChannelID)()
+	msg, err := w.Ses.ChannelMessageEditEmbed(w.ChannelID, message.ID, embed)
+	w.rendered(err)
+	return msg, wrapErr(err, "widget: update embed on message %s", message.ID)
+}
+
+// UpdateMessage updates both the content and the embed of the original message
+//    content: New content to replace w.Content
+//    embed  : New embed object to replace w.Embed
+func (w *Widget) UpdateMessage(content string, embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
+	message := w.message()
+	if message == nil {
 		return nil, ErrNilMessage
 	}
-	return w.Ses.ChannelMessageEditEmbed(w.ChannelID, w.Message.ID, embed)
+	embeds := []*discordgo.MessageEmbed{embed}
+	if w.Interaction != nil {
+		msg, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Content: &content,
+			Embeds:  &embeds,
+		})
+		w.rendered(err)
+		return msg, wrapErr(err, "widget: update message of interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(message.ChannelID)()
+	msg, err := w.Ses.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:      message.ID,
+		Channel: message.ChannelID,
+		Content: &content,
+		Embeds:  &embeds,
+	})
+	w.rendered(err)
+	return msg, wrapErr(err, "widget: update message %s", message.ID)
+}
+
+// UpdateEmbedWithFiles updates the embed and replaces the
+// attachments of the original message with files
+//    embed: New embed object to replace w.Embed
+//    files: New attachments, the previous ones are removed
+func (w *Widget) UpdateEmbedWithFiles(embed *discordgo.MessageEmbed, files []*discordgo.File) (*discordgo.Message, error) {
+	message := w.message()
+	if message == nil {
+		return nil, ErrNilMessage
+	}
+	embeds := []*discordgo.MessageEmbed{embed}
+	attachments := []*discordgo.MessageAttachment{}
+	if w.Interaction != nil {
+		msg, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Embeds:      &embeds,
+			Files:       files,
+			Attachments: &attachments,
+		})
+		w.rendered(err)
+		return msg, wrapErr(err, "widget: update embed and files of interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(message.ChannelID)()
+	msg, err := w.Ses.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:          message.ID,
+		Channel:     message.ChannelID,
+		Embeds:      &embeds,
+		Files:       files,
+		Attachments: &attachments,
+	})
+	w.rendered(err)
+	return msg, wrapErr(err, "widget: update embed and files on message %s", message.ID)
+}
+
+// UpdateContent updates the content of the original message
+//    content: New content to replace w.Content
+func (w *Widget) UpdateContent(content string) (*discordgo.Message, error) {
+	message := w.message()
+	if message == nil {
+		return nil, ErrNilMessage
+	}
+	if w.Interaction != nil {
+		msg, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Content: &content,
+		})
+		w.rendered(err)
+		return msg, wrapErr(err, "widget: update content of interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(w.ChannelID)()
+	msg, err := w.Ses.ChannelMessageEdit(w.ChannelID, message.ID, content)
+	w.rendered(err)
+	return msg, wrapErr(err, "widget: update content on message %s", message.ID)
+}
+
+// messageUpdate holds the parts of the widget's message to edit
+// in one request, nil parts are left unchanged
+type messageUpdate struct {
+	content    *string
+	embeds     *[]*discordgo.MessageEmbed
+	components *[]discordgo.MessageComponent
+	// files replace the message's attachments when replaceFiles is set
+	files        []*discordgo.File
+	replaceFiles bool
+}
+
+// update edits the parts of the original message set in u
+//    u: parts of the message to edit
+func (w *Widget) update(u messageUpdate) (*discordgo.Message, error) {
+	message := w.message()
+	if message == nil {
+		return nil, ErrNilMessage
+	}
+	if u.components != nil {
+		w.Lock()
+		w.Components = *u.components
+		w.Unlock()
+	}
+	var attachments *[]*discordgo.MessageAttachment
+	if u.replaceFiles {
+		attachments = &[]*discordgo.MessageAttachment{}
+	}
+	if w.Interaction != nil {
+		msg, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Content:     u.content,
+			Embeds:      u.embeds,
+			Components:  u.components,
+			Files:       u.files,
+			Attachments: attachments,
+		})
+		w.rendered(err)
+		return msg, wrapErr(err, "widget: update interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(message.ChannelID)()
+	msg, err := w.Ses.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:          message.ID,
+		Channel:     message.ChannelID,
+		Content:     u.content,
+		Embeds:      u.embeds,
+		Components:  u.components,
+		Files:       u.files,
+		Attachments: attachments,
+	})
+	w.rendered(err)
+	return msg, wrapErr(err, "widget: update message %s", message.ID)
+}
+
+// DeleteMessage deletes the widget's message
+func (w *Widget) DeleteMessage() error {
+	message := w.message()
+	if message == nil {
+		return ErrNilMessage
+	}
+	if w.Interaction != nil {
+		err := w.Ses.InteractionResponseDelete(w.Interaction)
+		return wrapErr(err, "widget: delete response to interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(message.ChannelID)()
+	err := w.Ses.ChannelMessageDelete(message.ChannelID, message.ID)
+	return wrapErr(err, "widget: delete message %s", message.ID)
+}
+
+// RemoveComponents removes all components from the widget's message
+func (w *Widget) RemoveComponents() error {
+	return w.UpdateComponents([]discordgo.MessageComponent{})
+}
+
+// UpdateComponents replaces the components of the widget's message
+//    components: components to show on the message
+func (w *Widget) UpdateComponents(components []discordgo.MessageComponent) error {
+	message := w.message()
+	if message == nil {
+		return ErrNilMessage
+	}
+	w.Lock()
+	w.Components = components
+	w.Unlock()
+	if w.Interaction != nil {
+		_, err := w.Ses.InteractionResponseEdit(w.Interaction, &discordgo.WebhookEdit{
+			Components: &components,
+		})
+		w.rendered(err)
+		return wrapErr(err, "widget: update components of interaction %s", w.Interaction.ID)
+	}
+	defer lockChannel(message.ChannelID)()
+	_, err := w.Ses.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         message.ID,
+		Channel:    message.ChannelID,
+		Components: &components,
+	})
+	w.rendered(err)
+	return wrapErr(err, "widget: update components on message %s", message.ID)
 }
 
 // Reset resets timeout ticker by duration. Returns ErrTickerNotSet when ticker is nil